@@ -0,0 +1,73 @@
+package logw
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Source of LogWriter time-stamps
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// Clock that returns current system time
+// Used by LogWriter by default
+var RealClock Clock = realClock{}
+
+// Sets LogWriter clock
+// Nil clock resets LogWriter clock to RealClock
+func WithClock(clock Clock) Setting {
+	return func(w *logWriter) {
+		if clock == nil {
+			clock = RealClock
+		}
+
+		w.clock = clock
+	}
+}
+
+// Takes record time-stamp from tag with key instead of clock
+// Tag value should be RFC3339 time-stamp or unix time-stamp in seconds
+// Tag is removed from record
+// If record has no such tag or its value can not be parsed clock is used
+func TimeFromTag(key string) Setting {
+	return func(w *logWriter) {
+		w.timeTag = key
+	}
+}
+
+// Takes record time-stamp from "time" tag of incoming record
+// Use with JSONInput to keep time-stamps of records ingested from other loggers
+func TimeFromRecord() Setting {
+	return TimeFromTag(TimeTagKey)
+}
+
+func (w *logWriter) recordTime(tags []Tag) (time.Time, []Tag) {
+	if w.timeTag == "" {
+		return w.clock.Now(), tags
+	}
+
+	for i, tag := range tags {
+		if tag.Key != w.timeTag {
+			continue
+		}
+
+		raw := tag.Value
+		if tag.Type == "string" {
+			raw = []byte(strconv.Quote(string(tag.Value)))
+		}
+
+		if timeStamp, ok := parseJSONTime(json.RawMessage(raw)); ok {
+			return timeStamp.Local(), append(tags[:i:i], tags[i+1:]...)
+		}
+	}
+
+	return w.clock.Now(), tags
+}
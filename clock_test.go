@@ -0,0 +1,132 @@
+package logw_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/logwtest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestClock(t *testing.T) {
+	suite.Run(t, new(clockSuite))
+}
+
+type clockSuite struct {
+	suite.Suite
+
+	b *bytes.Buffer
+}
+
+func (s *clockSuite) SetupTest() {
+	s.b = new(bytes.Buffer)
+}
+
+func (s *clockSuite) TestFakeClock() {
+	clock := logwtest.NewFakeClock(time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC))
+	log := s.logger(logw.WithClock(clock))
+
+	log.Println(logw.Info, "first")
+	clock.Advance(time.Minute)
+	log.Println(logw.Info, "second")
+	clock.Set(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	clock.AutoAdvance(time.Second)
+	log.Println(logw.Info, "third")
+	log.Println(logw.Info, "fourth")
+
+	s.Equal(
+		[]any{
+			"2022-10-01T12:00:00Z",
+			"2022-10-01T12:01:00Z",
+			"2023-01-01T00:00:00Z",
+			"2023-01-01T00:00:01Z",
+		},
+		s.dates(),
+	)
+}
+
+func (s *clockSuite) TestTimeFromTag() {
+	clock := logwtest.NewFakeClock(time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC))
+	log := s.logger(logw.WithClock(clock), logw.TimeFromTag("ts"))
+
+	log.Println(logw.Info.WithString("ts", "2021-05-01T10:00:00.5+02:00"), "string")
+	log.Println(logw.Info.WithInt("ts", 1600000000), "epoch")
+	log.Println(logw.Info.WithString("ts", "yesterday"), "invalid")
+	log.Println(logw.Info, "missing")
+
+	s.Equal(
+		[]any{
+			"2021-05-01T08:00:00.5Z",
+			"2020-09-13T12:26:40Z",
+			"2022-10-01T12:00:00Z",
+			"2022-10-01T12:00:00Z",
+		},
+		s.dates(),
+	)
+
+	records := s.records()
+	s.NotContains(records[0], "ts")
+	s.NotContains(records[1], "ts")
+	s.Equal([]any{"yesterday"}, records[2]["ts"])
+}
+
+func (s *clockSuite) TestTimeFromRecord() {
+	w := logw.JSONInput(s.writer(logw.TimeFromRecord()))
+
+	_, err := w.Write([]byte(`{"level":"info","ts":1600000000.25,"msg":"test","user":"test"}` + "\n"))
+	s.Require().NoError(err)
+
+	s.Equal([]any{"2020-09-13T12:26:40.25Z"}, s.dates())
+	s.NotContains(s.records()[0], logw.TimeTagKey)
+}
+
+func (s *clockSuite) TestRealClock() {
+	before := time.Now()
+	now := logw.RealClock.Now()
+
+	s.False(now.Before(before))
+
+	s.logger(logw.WithClock(nil)).Println(logw.Info, "test")
+	s.Len(s.dates(), 1)
+}
+
+func (s *clockSuite) logger(settings ...logw.Setting) *log.Logger {
+	return log.New(s.writer(settings...), "", 0)
+}
+
+func (s *clockSuite) writer(settings ...logw.Setting) io.Writer {
+	return logw.LogWriter(
+		context.TODO(),
+		s.b,
+		logw.Option(logw.LevelInfo, logw.JSONFormatter, time.RFC3339Nano),
+		append([]logw.Setting{logw.TimePrecision(0)}, settings...)...,
+	)
+}
+
+func (s *clockSuite) dates() []any {
+	dates := []any{}
+	for _, record := range s.records() {
+		dates = append(dates, record["date"])
+	}
+
+	return dates
+}
+
+func (s *clockSuite) records() []map[string]any {
+	records := []map[string]any{}
+	for _, line := range strings.Split(strings.TrimRight(s.b.String(), "\n"), "\n") {
+		record := make(map[string]any)
+		s.Require().NoError(json.Unmarshal([]byte(line), &record))
+
+		records = append(records, record)
+	}
+
+	return records
+}
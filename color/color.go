@@ -1,6 +1,9 @@
 package color
 
-import "regexp"
+import (
+	"regexp"
+	"sync"
+)
 
 type Color string
 
@@ -24,7 +27,12 @@ const (
 	ColorFatal Color = ANSIFontBold + ANSIColorRed
 )
 
-var ansiiColorMatch = regexp.MustCompile("\u001B\\[[;\\d]*m")
+var (
+	ansiiColorMatch = regexp.MustCompile("\u001B\\[[;\\d]*m")
+
+	levelColorsMu sync.RWMutex
+	levelColors   = make(map[int]Color)
+)
 
 // Returns text prepended by ANSI color code and appended by ANSI color reset code
 func ColorizeText(color Color, text string) string {
@@ -36,7 +44,21 @@ func ClearColors(text string) string {
 	return ansiiColorMatch.ReplaceAllString(text, "")
 }
 
+// Sets color for custom level code
+// Overrides default level colors returned by GetLevelColor
+func SetLevelColor(level int, color Color) {
+	levelColorsMu.Lock()
+	defer levelColorsMu.Unlock()
+
+	levelColors[level] = color
+}
+
+// Returns color for level code
 func GetLevelColor(level int) Color {
+	if color, ok := registeredLevelColor(level); ok {
+		return color
+	}
+
 	switch level {
 	case 1:
 		return ColorDebug
@@ -54,3 +76,11 @@ func GetLevelColor(level int) Color {
 
 	return ColorFatal
 }
+
+func registeredLevelColor(level int) (Color, bool) {
+	levelColorsMu.RLock()
+	defer levelColorsMu.RUnlock()
+
+	color, ok := levelColors[level]
+	return color, ok
+}
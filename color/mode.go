@@ -0,0 +1,70 @@
+package color
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// Color output mode
+type Mode int
+
+const (
+	// Colors are used if output is a terminal and environment allows colors
+	Auto Mode = iota
+	// Colors are always used
+	Always
+	// Colors are never used
+	Never
+)
+
+// Returns true if colors should be used for w in provided mode
+// In Auto mode honours NO_COLOR, FORCE_COLOR, CLICOLOR_FORCE, CLICOLOR and TERM=dumb conventions
+// and uses colors only if w is a terminal
+func Enabled(w io.Writer, mode Mode) bool {
+	switch mode {
+	case Always:
+		return true
+	case Never:
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	if isSet("FORCE_COLOR") || isSet("CLICOLOR_FORCE") {
+		return true
+	}
+
+	if os.Getenv("CLICOLOR") == "0" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	return IsTerminal(w)
+}
+
+// Returns true if w is a terminal
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(interface{ Stat() (os.FileInfo, error) })
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func isSet(env string) bool {
+	v, ok := os.LookupEnv(env)
+	if !ok {
+		return false
+	}
+
+	v = strings.ToLower(strings.TrimSpace(v))
+	return v != "0" && v != "false"
+}
@@ -0,0 +1,87 @@
+package color_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/andriiyaremenko/logwriter/color"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestMode(t *testing.T) {
+	suite.Run(t, new(modeSuite))
+}
+
+type modeSuite struct {
+	suite.Suite
+}
+
+func (s *modeSuite) SetupTest() {
+	for _, env := range []string{"NO_COLOR", "FORCE_COLOR", "CLICOLOR_FORCE", "CLICOLOR", "TERM"} {
+		s.unsetenv(env)
+	}
+}
+
+func (s *modeSuite) TestExplicitModes() {
+	s.T().Setenv("NO_COLOR", "1")
+
+	s.True(color.Enabled(new(bytes.Buffer), color.Always))
+	s.False(color.Enabled(new(bytes.Buffer), color.Never))
+}
+
+func (s *modeSuite) TestAutoNotTerminal() {
+	s.False(color.Enabled(new(bytes.Buffer), color.Auto))
+
+	f, err := os.CreateTemp(s.T().TempDir(), "log")
+	s.Require().NoError(err)
+	defer f.Close()
+
+	s.False(color.IsTerminal(f))
+	s.False(color.Enabled(f, color.Auto))
+}
+
+func (s *modeSuite) TestAutoForceColor() {
+	s.T().Setenv("FORCE_COLOR", "1")
+	s.True(color.Enabled(new(bytes.Buffer), color.Auto))
+
+	s.T().Setenv("FORCE_COLOR", "0")
+	s.False(color.Enabled(new(bytes.Buffer), color.Auto))
+
+	s.unsetenv("FORCE_COLOR")
+	s.T().Setenv("CLICOLOR_FORCE", "1")
+	s.True(color.Enabled(new(bytes.Buffer), color.Auto))
+}
+
+func (s *modeSuite) TestAutoNoColorWins() {
+	s.T().Setenv("FORCE_COLOR", "1")
+	s.T().Setenv("NO_COLOR", "1")
+
+	s.False(color.Enabled(new(bytes.Buffer), color.Auto))
+
+	s.T().Setenv("NO_COLOR", "")
+	s.True(color.Enabled(new(bytes.Buffer), color.Auto))
+}
+
+func (s *modeSuite) TestAutoTerminal() {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		s.T().Skip("no terminal available")
+	}
+	defer tty.Close()
+
+	s.True(color.IsTerminal(tty))
+	s.True(color.Enabled(tty, color.Auto))
+
+	s.T().Setenv("CLICOLOR", "0")
+	s.False(color.Enabled(tty, color.Auto))
+
+	s.unsetenv("CLICOLOR")
+	s.T().Setenv("TERM", "dumb")
+	s.False(color.Enabled(tty, color.Auto))
+}
+
+func (s *modeSuite) unsetenv(env string) {
+	s.T().Setenv(env, "")
+	s.Require().NoError(os.Unsetenv(env))
+}
@@ -0,0 +1,416 @@
+package color
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Terminal color depth
+type Depth int
+
+const (
+	// 16 basic ANSI colors
+	Depth16 Depth = iota
+	// 256-color palette
+	Depth256
+	// 24-bit RGB colors
+	DepthTrueColor
+)
+
+type paintKind uint8
+
+const (
+	paintNone paintKind = iota
+	paintBasic
+	paintIndexed
+	paintRGB
+)
+
+// Terminal color: one of 16 basic ANSI colors, 256-color palette index or 24-bit RGB color
+// Zero value means no color
+type Paint struct {
+	kind    paintKind
+	r, g, b uint8
+}
+
+// Returns one of 16 basic ANSI colors
+// Codes 0-7 are normal colors, codes 8-15 are bright colors
+func Basic(code uint8) Paint {
+	return Paint{kind: paintBasic, r: code % 16}
+}
+
+// Returns 256-color palette color
+func Indexed(index uint8) Paint {
+	return Paint{kind: paintIndexed, r: index}
+}
+
+// Returns 24-bit RGB color
+func RGB(r, g, b uint8) Paint {
+	return Paint{kind: paintRGB, r: r, g: g, b: b}
+}
+
+// Returns RGB color parsed from "#rrggbb" hex string
+func Hex(hex string) (Paint, bool) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return Paint{}, false
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return Paint{}, false
+	}
+
+	return RGB(uint8(v>>16), uint8(v>>8), uint8(v)), true
+}
+
+// Returns paint degraded to color depth
+func (p Paint) Degrade(depth Depth) Paint {
+	switch {
+	case p.kind == paintRGB && depth == Depth256:
+		return Indexed(rgbToIndex(p.r, p.g, p.b))
+	case p.kind == paintRGB && depth == Depth16:
+		return Basic(rgbToBasic(p.r, p.g, p.b))
+	case p.kind == paintIndexed && depth == Depth16:
+		if p.r < 16 {
+			return Basic(p.r)
+		}
+
+		r, g, b := indexToRGB(p.r)
+		return Basic(rgbToBasic(r, g, b))
+	}
+
+	return p
+}
+
+func (p Paint) code(background bool) string {
+	switch p.kind {
+	case paintBasic:
+		base := 30
+		if background {
+			base = 40
+		}
+
+		if p.r >= 8 {
+			return strconv.Itoa(base + 60 + int(p.r) - 8)
+		}
+
+		return strconv.Itoa(base + int(p.r))
+	case paintIndexed:
+		if background {
+			return "48;5;" + strconv.Itoa(int(p.r))
+		}
+
+		return "38;5;" + strconv.Itoa(int(p.r))
+	case paintRGB:
+		prefix := "38;2;"
+		if background {
+			prefix = "48;2;"
+		}
+
+		return prefix + strconv.Itoa(int(p.r)) + ";" + strconv.Itoa(int(p.g)) + ";" + strconv.Itoa(int(p.b))
+	}
+
+	return ""
+}
+
+// Font style and colors
+// Zero value means no styling
+type Style struct {
+	Foreground Paint
+	Background Paint
+	Bold       bool
+	Faint      bool
+	Italic     bool
+	Underline  bool
+}
+
+// Returns ANSI escape sequence for style rendered with color depth
+// Returns empty Color if style has no attributes
+func (s Style) Color(depth Depth) Color {
+	codes := make([]string, 0, 6)
+
+	if s.Bold {
+		codes = append(codes, "1")
+	}
+
+	if s.Faint {
+		codes = append(codes, "2")
+	}
+
+	if s.Italic {
+		codes = append(codes, "3")
+	}
+
+	if s.Underline {
+		codes = append(codes, "4")
+	}
+
+	if code := s.Foreground.Degrade(depth).code(false); code != "" {
+		codes = append(codes, code)
+	}
+
+	if code := s.Background.Degrade(depth).code(true); code != "" {
+		codes = append(codes, code)
+	}
+
+	if len(codes) == 0 {
+		return ""
+	}
+
+	return Color("\033[" + strings.Join(codes, ";") + "m")
+}
+
+// Text output color theme
+// Levels without style use colors returned by GetLevelColor
+// Colors registered with SetLevelColor take precedence over theme level styles
+type Theme struct {
+	Levels    map[int]Style
+	TimeStamp Style
+	TagKey    Style
+	String    Style
+	Number    Style
+	Bool      Style
+	Error     Style
+	JSON      Style
+	Message   Style
+}
+
+// Theme rendered for color depth
+type Palette struct {
+	levels map[int]Color
+
+	TimeStamp Color
+	TagKey    Color
+	String    Color
+	Number    Color
+	Bool      Color
+	Error     Color
+	JSON      Color
+	Message   Color
+}
+
+// Returns theme rendered for color depth
+func (t Theme) Palette(depth Depth) *Palette {
+	p := &Palette{
+		levels:    make(map[int]Color, len(t.Levels)),
+		TimeStamp: t.TimeStamp.Color(depth),
+		TagKey:    t.TagKey.Color(depth),
+		String:    t.String.Color(depth),
+		Number:    t.Number.Color(depth),
+		Bool:      t.Bool.Color(depth),
+		Error:     t.Error.Color(depth),
+		JSON:      t.JSON.Color(depth),
+		Message:   t.Message.Color(depth),
+	}
+
+	for level, style := range t.Levels {
+		p.levels[level] = style.Color(depth)
+	}
+
+	return p
+}
+
+// Returns color for level code
+func (p *Palette) Level(level int) Color {
+	if color, ok := registeredLevelColor(level); ok {
+		return color
+	}
+
+	if color, ok := p.levels[level]; ok {
+		return color
+	}
+
+	return GetLevelColor(level)
+}
+
+var (
+	// Theme used by text formatter by default
+	// Colors level and time-stamp with 16 basic colors
+	DefaultTheme = Theme{
+		TimeStamp: Style{Foreground: Basic(8)},
+	}
+
+	// Theme for terminals with dark background
+	DarkTheme = Theme{
+		Levels: map[int]Style{
+			0: {Foreground: RGB(0x6c, 0x6c, 0x6c)},
+			1: {Foreground: RGB(0x5f, 0xd7, 0xff)},
+			2: {Foreground: RGB(0x87, 0xd7, 0x5f)},
+			3: {Foreground: RGB(0xff, 0xd7, 0x5f)},
+			4: {Foreground: RGB(0xff, 0x5f, 0x5f)},
+			5: {Foreground: RGB(0xff, 0x5f, 0x5f), Bold: true},
+		},
+		TimeStamp: Style{Foreground: RGB(0x80, 0x80, 0x80)},
+		TagKey:    Style{Foreground: RGB(0x87, 0xaf, 0xd7)},
+		String:    Style{Foreground: RGB(0xd7, 0xaf, 0x87)},
+		Number:    Style{Foreground: RGB(0xaf, 0x87, 0xff)},
+		Bool:      Style{Foreground: RGB(0x5f, 0xaf, 0xaf)},
+		Error:     Style{Foreground: RGB(0xff, 0x5f, 0x5f)},
+		JSON:      Style{Foreground: RGB(0xbc, 0xbc, 0xbc)},
+		Message:   Style{Bold: true},
+	}
+
+	// Theme for terminals with light background
+	LightTheme = Theme{
+		Levels: map[int]Style{
+			0: {Foreground: RGB(0x8a, 0x8a, 0x8a)},
+			1: {Foreground: RGB(0x00, 0x5f, 0x87)},
+			2: {Foreground: RGB(0x00, 0x87, 0x00)},
+			3: {Foreground: RGB(0xaf, 0x5f, 0x00)},
+			4: {Foreground: RGB(0xd7, 0x00, 0x00)},
+			5: {Foreground: RGB(0xd7, 0x00, 0x00), Bold: true},
+		},
+		TimeStamp: Style{Foreground: RGB(0x8a, 0x8a, 0x8a)},
+		TagKey:    Style{Foreground: RGB(0x00, 0x5f, 0xaf)},
+		String:    Style{Foreground: RGB(0x87, 0x5f, 0x00)},
+		Number:    Style{Foreground: RGB(0x87, 0x00, 0xaf)},
+		Bool:      Style{Foreground: RGB(0x00, 0x87, 0x87)},
+		Error:     Style{Foreground: RGB(0xd7, 0x00, 0x00)},
+		JSON:      Style{Foreground: RGB(0x4e, 0x4e, 0x4e)},
+		Message:   Style{Bold: true},
+	}
+
+	// Solarized color scheme theme
+	SolarizedTheme = Theme{
+		Levels: map[int]Style{
+			0: {Foreground: RGB(0x58, 0x6e, 0x75)},
+			1: {Foreground: RGB(0x26, 0x8b, 0xd2)},
+			2: {Foreground: RGB(0x85, 0x99, 0x00)},
+			3: {Foreground: RGB(0xb5, 0x89, 0x00)},
+			4: {Foreground: RGB(0xdc, 0x32, 0x2f)},
+			5: {Foreground: RGB(0xd3, 0x36, 0x82), Bold: true},
+		},
+		TimeStamp: Style{Foreground: RGB(0x58, 0x6e, 0x75)},
+		TagKey:    Style{Foreground: RGB(0x26, 0x8b, 0xd2)},
+		String:    Style{Foreground: RGB(0x2a, 0xa1, 0x98)},
+		Number:    Style{Foreground: RGB(0x6c, 0x71, 0xc4)},
+		Bool:      Style{Foreground: RGB(0xcb, 0x4b, 0x16)},
+		Error:     Style{Foreground: RGB(0xdc, 0x32, 0x2f)},
+		JSON:      Style{Foreground: RGB(0x93, 0xa1, 0xa1)},
+		Message:   Style{Foreground: RGB(0x93, 0xa1, 0xa1)},
+	}
+
+	// High contrast theme using bold bright basic colors
+	HighContrastTheme = Theme{
+		Levels: map[int]Style{
+			0: {Foreground: Basic(15)},
+			1: {Foreground: Basic(14), Bold: true},
+			2: {Foreground: Basic(10), Bold: true},
+			3: {Foreground: Basic(0), Background: Basic(11), Bold: true},
+			4: {Foreground: Basic(15), Background: Basic(9), Bold: true},
+			5: {Foreground: Basic(15), Background: Basic(1), Bold: true, Underline: true},
+		},
+		TimeStamp: Style{Foreground: Basic(15)},
+		TagKey:    Style{Foreground: Basic(14), Bold: true},
+		String:    Style{Foreground: Basic(11)},
+		Number:    Style{Foreground: Basic(13)},
+		Bool:      Style{Foreground: Basic(10)},
+		Error:     Style{Foreground: Basic(9), Bold: true},
+		JSON:      Style{Foreground: Basic(15)},
+		Message:   Style{Foreground: Basic(15), Bold: true},
+	}
+)
+
+// Returns color depth supported by terminal
+// Uses COLORTERM and TERM environment variables
+func DetectDepth() Depth {
+	colorTerm := strings.ToLower(os.Getenv("COLORTERM"))
+	if colorTerm == "truecolor" || colorTerm == "24bit" {
+		return DepthTrueColor
+	}
+
+	term := strings.ToLower(os.Getenv("TERM"))
+	switch {
+	case strings.Contains(term, "truecolor") || strings.Contains(term, "24bit") || strings.Contains(term, "direct"):
+		return DepthTrueColor
+	case strings.Contains(term, "256"):
+		return Depth256
+	}
+
+	return Depth16
+}
+
+var (
+	cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+	basicRGB   = [16][3]uint8{
+		{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+		{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+		{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+		{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+	}
+)
+
+func indexToRGB(index uint8) (uint8, uint8, uint8) {
+	switch {
+	case index < 16:
+		c := basicRGB[index]
+		return c[0], c[1], c[2]
+	case index < 232:
+		i := index - 16
+		return cubeLevels[i/36], cubeLevels[i/6%6], cubeLevels[i%6]
+	}
+
+	v := 8 + 10*(index-232)
+	return v, v, v
+}
+
+func rgbToIndex(r, g, b uint8) uint8 {
+	cube := func(v uint8) uint8 {
+		best := uint8(0)
+		for i, level := range cubeLevels {
+			if absDiff(v, level) < absDiff(v, cubeLevels[best]) {
+				best = uint8(i)
+			}
+		}
+
+		return best
+	}
+
+	ri, gi, bi := cube(r), cube(g), cube(b)
+	cubeIndex := 16 + 36*ri + 6*gi + bi
+
+	avg := (int(r) + int(g) + int(b)) / 3
+	grayIndex := uint8(23)
+	if avg < 8 {
+		grayIndex = 0
+	} else if avg < 238 {
+		grayIndex = uint8((avg - 8 + 5) / 10)
+	}
+
+	grayIndex += 232
+
+	cr, cg, cb := indexToRGB(cubeIndex)
+	gr, gg, gb := indexToRGB(grayIndex)
+	if distance(r, g, b, gr, gg, gb) < distance(r, g, b, cr, cg, cb) {
+		return grayIndex
+	}
+
+	return cubeIndex
+}
+
+func rgbToBasic(r, g, b uint8) uint8 {
+	best := uint8(0)
+	bestDistance := -1
+	for i, c := range basicRGB {
+		if d := distance(r, g, b, c[0], c[1], c[2]); bestDistance < 0 || d < bestDistance {
+			best, bestDistance = uint8(i), d
+		}
+	}
+
+	return best
+}
+
+func distance(r1, g1, b1, r2, g2, b2 uint8) int {
+	dr, dg, db := int(r1)-int(r2), int(g1)-int(g2), int(b1)-int(b2)
+	return dr*dr + dg*dg + db*db
+}
+
+func absDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+
+	return b - a
+}
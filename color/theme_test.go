@@ -0,0 +1,106 @@
+package color_test
+
+import (
+	"testing"
+
+	"github.com/andriiyaremenko/logwriter/color"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestTheme(t *testing.T) {
+	suite.Run(t, new(themeSuite))
+}
+
+type themeSuite struct {
+	suite.Suite
+}
+
+func (s *themeSuite) TestStyleColor() {
+	s.Equal(color.Color(""), color.Style{}.Color(color.DepthTrueColor))
+	s.Equal(color.ANSIColorGray, color.Style{Foreground: color.Basic(8)}.Color(color.Depth16))
+	s.Equal(color.ANSIColorRed, color.Style{Foreground: color.Basic(1)}.Color(color.DepthTrueColor))
+	s.Equal(
+		color.Color("\033[1;4;38;5;208;48;5;17m"),
+		color.Style{
+			Foreground: color.Indexed(208),
+			Background: color.Indexed(17),
+			Bold:       true,
+			Underline:  true,
+		}.Color(color.Depth256),
+	)
+	s.Equal(
+		color.Color("\033[3;38;2;18;52;86m"),
+		color.Style{Foreground: color.RGB(0x12, 0x34, 0x56), Italic: true}.Color(color.DepthTrueColor),
+	)
+}
+
+func (s *themeSuite) TestDegrade() {
+	s.Equal(color.Indexed(196), color.RGB(255, 0, 0).Degrade(color.Depth256))
+	s.Equal(color.Indexed(244), color.RGB(128, 128, 128).Degrade(color.Depth256))
+	s.Equal(color.Basic(9), color.RGB(250, 10, 10).Degrade(color.Depth16))
+	s.Equal(color.Basic(4), color.Indexed(4).Degrade(color.Depth16))
+	s.Equal(color.Basic(15), color.Indexed(231).Degrade(color.Depth16))
+	s.Equal(color.Basic(0), color.Indexed(232).Degrade(color.Depth16))
+	s.Equal(color.RGB(1, 2, 3), color.RGB(1, 2, 3).Degrade(color.DepthTrueColor))
+	s.Equal(color.Paint{}, color.Paint{}.Degrade(color.Depth16))
+}
+
+func (s *themeSuite) TestHex() {
+	p, ok := color.Hex("#268bd2")
+	s.True(ok)
+	s.Equal(color.RGB(0x26, 0x8b, 0xd2), p)
+
+	_, ok = color.Hex("blue")
+	s.False(ok)
+}
+
+func (s *themeSuite) TestPalette() {
+	for _, theme := range []color.Theme{
+		color.DarkTheme,
+		color.LightTheme,
+		color.SolarizedTheme,
+		color.HighContrastTheme,
+	} {
+		for _, depth := range []color.Depth{color.Depth16, color.Depth256, color.DepthTrueColor} {
+			palette := theme.Palette(depth)
+
+			for level := 0; level <= 5; level++ {
+				s.NotEmpty(palette.Level(level))
+			}
+
+			s.NotEmpty(palette.TagKey)
+			s.NotEmpty(palette.String)
+			s.NotEmpty(palette.Number)
+			s.NotEmpty(palette.Error)
+		}
+	}
+
+	palette := color.DefaultTheme.Palette(color.Depth16)
+	s.Equal(color.ColorWarn, palette.Level(3))
+	s.Equal(color.ANSIColorGray, palette.TimeStamp)
+	s.Empty(palette.TagKey)
+	s.Empty(palette.Message)
+}
+
+func (s *themeSuite) TestPaletteRegisteredLevelColor() {
+	color.SetLevelColor(41, color.ANSIColorPurple)
+
+	theme := color.Theme{Levels: map[int]color.Style{41: {Foreground: color.Basic(2)}, 42: {Bold: true}}}
+	palette := theme.Palette(color.DepthTrueColor)
+
+	s.Equal(color.ANSIColorPurple, palette.Level(41))
+	s.Equal(color.ANSIFontBold, palette.Level(42))
+}
+
+func (s *themeSuite) TestDetectDepth() {
+	s.T().Setenv("COLORTERM", "truecolor")
+	s.T().Setenv("TERM", "xterm")
+	s.Equal(color.DepthTrueColor, color.DetectDepth())
+
+	s.T().Setenv("COLORTERM", "")
+	s.T().Setenv("TERM", "xterm-256color")
+	s.Equal(color.Depth256, color.DetectDepth())
+
+	s.T().Setenv("TERM", "xterm")
+	s.Equal(color.Depth16, color.DetectDepth())
+}
@@ -0,0 +1,129 @@
+package logw
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var levelPrefixMatch = regexp.MustCompile(
+	`^\s*(?:\[(\w+)\]:?|\((\w+)\):?|(\w+):)\s*`,
+)
+
+// Returns writers to attach to exec.Cmd Stdout and Stderr
+// Every line of command output is written to w as a separate record with "cmd", "pid" and "stream" tags
+// Stdout lines are logged with Info level, stderr lines with stderrLevel
+// If detectLevel is true, common level prefixes ("ERROR:", "[warn]", "(debug)")
+// and JSON lines with "level" field override default level
+// Lines that already contain logw level header keep their level and tags
+// Fatal levels are logged with Error level and "original_level" tag, so command output never triggers FatalHandler
+// Writes to w from both streams are serialized
+// Writers should be closed after exec.Cmd.Wait returns to flush incomplete lines
+func CommandWriters(cmd *exec.Cmd, w io.Writer, stderrLevel int, detectLevel bool) (stdout, stderr io.WriteCloser) {
+	mu := new(sync.Mutex)
+	stream := func(name string, level int) io.WriteCloser {
+		return LineWriter(
+			&commandStream{cmd: cmd, w: w, mu: mu, stream: name, level: level, detectLevel: detectLevel},
+			0,
+			0,
+		)
+	}
+
+	return stream("stdout", LevelInfo), stream("stderr", stderrLevel)
+}
+
+type commandStream struct {
+	cmd         *exec.Cmd
+	w           io.Writer
+	mu          *sync.Mutex
+	stream      string
+	level       int
+	detectLevel bool
+}
+
+func (s *commandStream) Write(p []byte) (int, error) {
+	line := strings.TrimRight(string(p), "\r\n")
+	level, message, tags := s.level, line, []Tag{}
+
+	if strings.Contains(line, logwHeader) || strings.Contains(line, logwHeaderV1) {
+		var m []byte
+
+		level, m, tags = parseLog([]byte(line))
+		message = string(m)
+	} else if s.detectLevel {
+		level, message = detectLevel(line, s.level)
+	}
+
+	pid := 0
+	if s.cmd.Process != nil {
+		pid = s.cmd.Process.Pid
+	}
+
+	level, originalLevel := ingestedLevel(level)
+
+	logLevel := Level(level)
+	for i := len(tags) - 1; i >= 0; i-- {
+		logLevel = logLevel.appendTag(tags[i].Key, string(tags[i].Value), tags[i].Type)
+	}
+
+	if originalLevel != "" {
+		logLevel = logLevel.WithString(OriginalLevelTagKey, originalLevel)
+	}
+
+	logLevel = logLevel.
+		WithString("cmd", filepath.Base(s.cmd.Path)).
+		WithInt("pid", pid).
+		WithString("stream", s.stream)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.w.Write([]byte(logLevel.WithMessage("%s", message))); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func detectLevel(line string, defaultLevel int) (int, string) {
+	trimmed := bytes.TrimSpace([]byte(line))
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var record map[string]any
+		if err := json.Unmarshal(trimmed, &record); err == nil {
+			for _, key := range []string{"level", "lvl", "severity"} {
+				name, ok := record[key].(string)
+				if !ok {
+					continue
+				}
+
+				if level, ok := levelFromAlias(name); ok {
+					return level, line
+				}
+			}
+		}
+
+		return defaultLevel, line
+	}
+
+	match := levelPrefixMatch.FindStringSubmatch(line)
+	if match == nil {
+		return defaultLevel, line
+	}
+
+	for _, name := range match[1:] {
+		if name == "" {
+			continue
+		}
+
+		if level, ok := levelFromAlias(name); ok {
+			return level, line[len(match[0]):]
+		}
+	}
+
+	return defaultLevel, line
+}
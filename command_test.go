@@ -0,0 +1,126 @@
+package logw_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/logwtest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCommandWriters(t *testing.T) {
+	suite.Run(t, new(commandWritersSuite))
+}
+
+type commandWritersSuite struct {
+	suite.Suite
+}
+
+func (s *commandWritersSuite) TestTagsAndDefaultLevels() {
+	records := s.run(false, `echo "hello"; echo "ERROR: bad" 1>&2`)
+	s.Require().Len(records, 2)
+
+	stdout, stderr := records[0], records[1]
+	if stdout["stream"].([]any)[0] != "stdout" {
+		stdout, stderr = stderr, stdout
+	}
+
+	s.Equal("info", stdout["level"])
+	s.Equal("hello", stdout["message"])
+	s.Equal([]any{"sh"}, stdout["cmd"])
+	s.NotZero(stdout["pid"].([]any)[0])
+
+	s.Equal("warn", stderr["level"])
+	s.Equal("ERROR: bad", stderr["message"])
+	s.Equal([]any{"stderr"}, stderr["stream"])
+	s.Equal(stdout["pid"], stderr["pid"])
+}
+
+func (s *commandWritersSuite) TestDetectLevel() {
+	records := s.run(
+		true,
+		`echo "ERROR: bad"; echo "[warn] careful"; echo "(debug) details"; `+
+			`echo '{"level":"error","msg":"json"}'; echo "[main] started"`,
+	)
+	s.Require().Len(records, 5)
+
+	s.Equal("error", records[0]["level"])
+	s.Equal("bad", records[0]["message"])
+	s.Equal("warn", records[1]["level"])
+	s.Equal("careful", records[1]["message"])
+	s.Equal("debug", records[2]["level"])
+	s.Equal("details", records[2]["message"])
+	s.Equal("error", records[3]["level"])
+	s.Equal("{\"level\":\"error\",\"msg\":\"json\"}", records[3]["message"])
+	s.Equal("info", records[4]["level"])
+	s.Equal("[main] started", records[4]["message"])
+}
+
+func (s *commandWritersSuite) TestPanicIsNotFatal() {
+	records := s.run(true, `echo "panic: boom"; echo '{"level":"dpanic","msg":"json"}'`)
+	s.Require().Len(records, 2)
+
+	s.Equal("error", records[0]["level"])
+	s.Equal("boom", records[0]["message"])
+	s.Equal("error", records[1]["level"])
+}
+
+func (s *commandWritersSuite) TestFatalDoesNotTriggerFatalHandler() {
+	recorder := new(logwtest.ExitRecorder)
+	h := logw.NewFatalHandler(1, time.Second)
+	h.Exit = recorder.Exit
+
+	line := logw.Fatal.WithString("foo", "bar").WithMessage("header")
+	records := s.run(
+		true,
+		`echo "FATAL: child failed" 1>&2; echo '{"level":"emerg","msg":"json"}'; printf '%s\n' '`+line+`'`,
+		logw.OnFatal(h),
+	)
+	s.Require().Len(records, 3)
+
+	for _, record := range records {
+		s.Equal("error", record["level"])
+		s.Equal([]any{"fatal"}, record["original_level"])
+	}
+
+	s.False(recorder.Exited())
+}
+
+func (s *commandWritersSuite) TestKeepsLogwHeaders() {
+	line := logw.Error.WithString("foo", "bar").WithMessage("test")
+	records := s.run(true, "printf '%s\\n' '"+line+"'")
+	s.Require().Len(records, 1)
+
+	s.Equal("error", records[0]["level"])
+	s.Equal("test", records[0]["message"])
+	s.Equal([]any{"bar"}, records[0]["foo"])
+	s.Equal([]any{"stdout"}, records[0]["stream"])
+}
+
+func (s *commandWritersSuite) run(detectLevel bool, script string, settings ...logw.Setting) []map[string]any {
+	b := new(bytes.Buffer)
+	option := logw.Option(logw.LevelDebug, logw.JSONFormatter, logw.NoDate)
+	cmd := exec.Command("sh", "-c", script)
+	stdout, stderr := logw.CommandWriters(cmd, logw.LogWriter(context.TODO(), b, option, settings...), logw.LevelWarn, detectLevel)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+
+	s.Require().NoError(cmd.Run())
+	s.Require().NoError(stdout.Close())
+	s.Require().NoError(stderr.Close())
+
+	records := []map[string]any{}
+	for _, line := range strings.Split(strings.TrimRight(b.String(), "\n"), "\n") {
+		record := make(map[string]any)
+		s.Require().NoError(json.Unmarshal([]byte(line), &record))
+
+		records = append(records, record)
+	}
+
+	return records
+}
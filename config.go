@@ -0,0 +1,624 @@
+package logw
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andriiyaremenko/logwriter/color"
+)
+
+// LogWriter configuration
+// Can be loaded from JSON file with LoadConfig and from LOGW_* environment variables with EnvConfig
+// Empty fields use defaults: "info" level, "json" format, "rfc3339" date layout and "stderr" sink
+type Config struct {
+	// Level name or code, e.g. "debug" or "1"
+	Level string `json:"level,omitempty"`
+	// Minimum level name or code of context tags, e.g. "info", see TagLevel
+	TagLevel string `json:"tag_level,omitempty"`
+	// Formatter name: "json", "text" or "console"
+	Format string `json:"format,omitempty"`
+	// Date layout name: "rfc3339", "rfc3339nano", "kitchen", "none",
+	// "unix_seconds", "unix_millis", "unix_nanos", "elapsed" or Go time layout
+	DateLayout string `json:"date_layout,omitempty"`
+	// Time zone: "utc", "local" or IANA time zone name
+	TimeZone string `json:"time_zone,omitempty"`
+	// Time-stamp precision duration, e.g. "1s" or "1us"
+	TimePrecision string `json:"time_precision,omitempty"`
+	// Color mode of text and console formatters: "auto", "always" or "never"
+	Color string `json:"color,omitempty"`
+	// Color theme of text and console formatters: "default", "dark", "light", "solarized" or "high_contrast"
+	Theme string `json:"theme,omitempty"`
+	// Outputs records are written to
+	Sinks []SinkConfig `json:"sinks,omitempty"`
+	// Records sampling, nil disables sampling
+	Sampling *SamplingConfig `json:"sampling,omitempty"`
+	// Keys of tags which values are redacted
+	Redact []string `json:"redact,omitempty"`
+	// Adds process tags to every record, see ProcessTags
+	Enrich bool `json:"enrich,omitempty"`
+	// Value of "service" tag added to every record
+	Service string `json:"service,omitempty"`
+	// Value of "env" tag added to every record
+	Env string `json:"env,omitempty"`
+}
+
+// LogWriter output configuration
+type SinkConfig struct {
+	// "stdout", "stderr" or file path
+	Output string `json:"output"`
+	// Overrides Config.Level for sink
+	Level string `json:"level,omitempty"`
+	// Overrides Config.TagLevel for sink
+	TagLevel string `json:"tag_level,omitempty"`
+	// Overrides Config.Format for sink
+	Format string `json:"format,omitempty"`
+}
+
+// Records sampling configuration
+// See NewSampler
+type SamplingConfig struct {
+	Initial    int `json:"initial"`
+	Thereafter int `json:"thereafter"`
+	// Tick duration, e.g. "1s", DefaultSamplingTick if empty
+	Tick string `json:"tick"`
+}
+
+// Invalid configuration field
+type FieldError struct {
+	Field  string
+	Value  string
+	Reason string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s %q: %s", e.Field, e.Value, e.Reason)
+}
+
+// Configuration validation error that lists every invalid field
+type ConfigError struct {
+	Fields []FieldError
+}
+
+func (e *ConfigError) Error() string {
+	errs := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		errs[i] = field.Error()
+	}
+
+	return "invalid logw configuration: " + strings.Join(errs, "; ")
+}
+
+func (e *ConfigError) add(field, value, reason string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Value: value, Reason: reason})
+}
+
+func (e *ConfigError) orNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+var dateLayouts = map[string]string{
+	"rfc3339":      time.RFC3339,
+	"rfc3339nano":  time.RFC3339Nano,
+	"kitchen":      time.Kitchen,
+	"none":         NoDate,
+	"unix_seconds": UnixSeconds,
+	"unix_millis":  UnixMillis,
+	"unix_nanos":   UnixNanos,
+	"elapsed":      Elapsed,
+}
+
+var colorModes = map[string]color.Mode{
+	"auto":   color.Auto,
+	"always": color.Always,
+	"never":  color.Never,
+}
+
+var themes = map[string]*color.Theme{
+	"default":       &color.DefaultTheme,
+	"dark":          &color.DarkTheme,
+	"light":         &color.LightTheme,
+	"solarized":     &color.SolarizedTheme,
+	"high_contrast": &color.HighContrastTheme,
+}
+
+// Reads JSON configuration from r
+// Unknown fields are reported as errors
+func ReadConfig(r io.Reader) (Config, error) {
+	var c Config
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&c); err != nil {
+		return Config{}, fmt.Errorf("invalid logw configuration: %w", err)
+	}
+
+	return c, nil
+}
+
+// Reads JSON configuration file
+func LoadConfig(path string) (Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Config{}, err
+	}
+	defer f.Close()
+
+	return ReadConfig(f)
+}
+
+// Returns configuration read from JSON file at LOGW_CONFIG path (if set)
+// with fields overridden by environment variables:
+//  LOGW_LEVEL, LOGW_TAG_LEVEL, LOGW_FORMAT, LOGW_DATE_LAYOUT, LOGW_TIME_ZONE, LOGW_TIME_PRECISION, LOGW_COLOR, LOGW_THEME,
+//  LOGW_SERVICE, LOGW_ENV, LOGW_ENRICH (boolean),
+//  LOGW_SINKS (comma separated outputs), LOGW_REDACT (comma separated tag keys),
+//  LOGW_SAMPLING_INITIAL, LOGW_SAMPLING_THEREAFTER, LOGW_SAMPLING_TICK
+func EnvConfig() (Config, error) {
+	var c Config
+
+	if path := os.Getenv("LOGW_CONFIG"); path != "" {
+		var err error
+		if c, err = LoadConfig(path); err != nil {
+			return Config{}, err
+		}
+	}
+
+	for env, field := range map[string]*string{
+		"LOGW_LEVEL":          &c.Level,
+		"LOGW_TAG_LEVEL":      &c.TagLevel,
+		"LOGW_FORMAT":         &c.Format,
+		"LOGW_DATE_LAYOUT":    &c.DateLayout,
+		"LOGW_TIME_ZONE":      &c.TimeZone,
+		"LOGW_TIME_PRECISION": &c.TimePrecision,
+		"LOGW_COLOR":          &c.Color,
+		"LOGW_THEME":          &c.Theme,
+		"LOGW_SERVICE":        &c.Service,
+		"LOGW_ENV":            &c.Env,
+	} {
+		if v, ok := os.LookupEnv(env); ok {
+			*field = v
+		}
+	}
+
+	if v, ok := os.LookupEnv("LOGW_SINKS"); ok {
+		c.Sinks = nil
+		for _, output := range splitList(v) {
+			c.Sinks = append(c.Sinks, SinkConfig{Output: output})
+		}
+	}
+
+	if v, ok := os.LookupEnv("LOGW_REDACT"); ok {
+		c.Redact = splitList(v)
+	}
+
+	errs := new(ConfigError)
+	if v, ok := os.LookupEnv("LOGW_ENRICH"); ok {
+		enrich, err := strconv.ParseBool(v)
+		if err != nil {
+			errs.add("LOGW_ENRICH", v, "not a boolean")
+		}
+
+		c.Enrich = enrich
+	}
+
+	for _, env := range []string{"LOGW_SAMPLING_INITIAL", "LOGW_SAMPLING_THEREAFTER"} {
+		v, ok := os.LookupEnv(env)
+		if !ok {
+			continue
+		}
+
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			errs.add(env, v, "not an integer")
+			continue
+		}
+
+		if c.Sampling == nil {
+			c.Sampling = new(SamplingConfig)
+		}
+
+		if env == "LOGW_SAMPLING_INITIAL" {
+			c.Sampling.Initial = n
+		} else {
+			c.Sampling.Thereafter = n
+		}
+	}
+
+	if v, ok := os.LookupEnv("LOGW_SAMPLING_TICK"); ok {
+		if c.Sampling == nil {
+			c.Sampling = new(SamplingConfig)
+		}
+
+		c.Sampling.Tick = v
+	}
+
+	return c, errs.orNil()
+}
+
+// Returns *ConfigError listing every invalid field or nil if configuration is valid
+func (c Config) Validate() error {
+	_, err := c.parse()
+	return err
+}
+
+// Validates configuration and opens its sinks
+func (c Config) Build() (*Pipeline, error) {
+	parsed, err := c.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	sinks := c.Sinks
+	if len(sinks) == 0 {
+		sinks = []SinkConfig{{Output: "stderr"}}
+	}
+
+	p := &Pipeline{config: c}
+	for i, sink := range sinks {
+		var out io.Writer
+		switch sink.Output {
+		case "stdout":
+			out = os.Stdout
+		case "stderr":
+			out = os.Stderr
+		default:
+			f, err := os.OpenFile(sink.Output, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+			if err != nil {
+				_ = p.Close()
+				return nil, err
+			}
+
+			p.closers = append(p.closers, f)
+			out = f
+		}
+
+		level, tagLevel, format := parsed.level, parsed.tagLevel, parsed.format
+		if sink.Level != "" {
+			level = parsed.sinkLevels[i]
+		}
+
+		if sink.TagLevel != "" {
+			level := parsed.sinkTagLevels[i]
+			tagLevel = &level
+		}
+
+		if sink.Format != "" {
+			format = strings.ToLower(sink.Format)
+		}
+
+		settings := append([]Setting(nil), parsed.settings...)
+		if c.Sampling != nil {
+			settings = append(settings, Sample(NewSampler(c.Sampling.Initial, c.Sampling.Thereafter, parsed.tick)))
+		}
+
+		if tagLevel != nil {
+			settings = append(settings, TagLevel(*tagLevel))
+		}
+
+		p.sinks = append(p.sinks, pipelineSink{
+			w:        out,
+			conf:     Option(level, parsed.formatter(format, out), parsed.dateLayout),
+			settings: settings,
+		})
+	}
+
+	return p, nil
+}
+
+type parsedConfig struct {
+	level         int
+	sinkLevels    map[int]int
+	tagLevel      *int
+	sinkTagLevels map[int]int
+	format        string
+	dateLayout    string
+	colorMode     color.Mode
+	theme         *color.Theme
+	tick          time.Duration
+	settings      []Setting
+}
+
+func (c Config) parse() (*parsedConfig, error) {
+	errs := new(ConfigError)
+	parsed := &parsedConfig{
+		level:         LevelInfo,
+		sinkLevels:    make(map[int]int),
+		sinkTagLevels: make(map[int]int),
+		format:        "json",
+		dateLayout:    time.RFC3339,
+	}
+
+	if c.Level != "" {
+		level, err := ParseLevel(c.Level)
+		if err != nil {
+			errs.add("level", c.Level, "unknown level")
+		}
+
+		parsed.level = level
+	}
+
+	if c.TagLevel != "" {
+		level, err := ParseLevel(c.TagLevel)
+		if err != nil {
+			errs.add("tag_level", c.TagLevel, "unknown level")
+		}
+
+		parsed.tagLevel = &level
+	}
+
+	if c.Format != "" {
+		if !isFormatName(c.Format) {
+			errs.add("format", c.Format, "unknown format, expected json, text or console")
+		}
+
+		parsed.format = strings.ToLower(c.Format)
+	}
+
+	if c.DateLayout != "" {
+		layout, ok := dateLayouts[strings.ToLower(c.DateLayout)]
+		if !ok {
+			layout = c.DateLayout
+			if programStart.Format(layout) == layout {
+				errs.add("date_layout", c.DateLayout, "unknown layout name and not a Go time layout")
+			}
+		}
+
+		parsed.dateLayout = layout
+	}
+
+	switch strings.ToLower(c.TimeZone) {
+	case "":
+	case "utc":
+		parsed.settings = append(parsed.settings, TimeZone(time.UTC))
+	case "local":
+		parsed.settings = append(parsed.settings, LocalTimeZone())
+	default:
+		setting, err := NamedTimeZone(c.TimeZone)
+		if err != nil {
+			errs.add("time_zone", c.TimeZone, "unknown time zone")
+		}
+
+		parsed.settings = append(parsed.settings, setting)
+	}
+
+	if c.TimePrecision != "" {
+		precision, err := time.ParseDuration(c.TimePrecision)
+		if err != nil {
+			errs.add("time_precision", c.TimePrecision, "not a duration")
+		}
+
+		parsed.settings = append(parsed.settings, TimePrecision(precision))
+	}
+
+	if c.Color != "" {
+		mode, ok := colorModes[strings.ToLower(c.Color)]
+		if !ok {
+			errs.add("color", c.Color, "unknown color mode, expected auto, always or never")
+		}
+
+		parsed.colorMode = mode
+	}
+
+	if c.Theme != "" {
+		theme, ok := themes[strings.ToLower(c.Theme)]
+		if !ok {
+			errs.add("theme", c.Theme, "unknown theme")
+		}
+
+		parsed.theme = theme
+	}
+
+	for i, sink := range c.Sinks {
+		field := "sinks[" + strconv.Itoa(i) + "]"
+		if sink.Output == "" {
+			errs.add(field+".output", sink.Output, "output is required")
+		}
+
+		if sink.Level != "" {
+			level, err := ParseLevel(sink.Level)
+			if err != nil {
+				errs.add(field+".level", sink.Level, "unknown level")
+			}
+
+			parsed.sinkLevels[i] = level
+		}
+
+		if sink.TagLevel != "" {
+			level, err := ParseLevel(sink.TagLevel)
+			if err != nil {
+				errs.add(field+".tag_level", sink.TagLevel, "unknown level")
+			}
+
+			parsed.sinkTagLevels[i] = level
+		}
+
+		if sink.Format != "" && !isFormatName(sink.Format) {
+			errs.add(field+".format", sink.Format, "unknown format, expected json, text or console")
+		}
+	}
+
+	if s := c.Sampling; s != nil {
+		if s.Initial < 0 {
+			errs.add("sampling.initial", strconv.Itoa(s.Initial), "must not be negative")
+		}
+
+		if s.Thereafter < 0 {
+			errs.add("sampling.thereafter", strconv.Itoa(s.Thereafter), "must not be negative")
+		}
+
+		if s.Initial == 0 && s.Thereafter == 0 {
+			errs.add("sampling.initial", strconv.Itoa(s.Initial), "initial and thereafter must not both be zero")
+		}
+
+		if s.Tick != "" {
+			tick, err := time.ParseDuration(s.Tick)
+			switch {
+			case err != nil:
+				errs.add("sampling.tick", s.Tick, "not a duration")
+			case tick <= 0:
+				errs.add("sampling.tick", s.Tick, "must be positive")
+			}
+
+			parsed.tick = tick
+		}
+	}
+
+	for i, key := range c.Redact {
+		if key == "" {
+			errs.add("redact["+strconv.Itoa(i)+"]", key, "tag key is required")
+		}
+	}
+
+	if len(c.Redact) > 0 {
+		parsed.settings = append(parsed.settings, Redact(c.Redact...))
+	}
+
+	switch {
+	case c.Enrich:
+		parsed.settings = append(parsed.settings, Enrich(c.Service, c.Env))
+	case c.Service != "" || c.Env != "":
+		parsed.settings = append(parsed.settings, addTags(serviceTags(c.Service, c.Env)))
+	}
+
+	if err := errs.orNil(); err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+func (p *parsedConfig) formatter(format string, w io.Writer) Formatter {
+	switch format {
+	case "text":
+		if p.theme == nil {
+			return NewTextFormatter(w, p.colorMode)
+		}
+
+		return NewThemedTextFormatter(w, p.colorMode, *p.theme)
+	case "console":
+		opts := []ConsoleOption{ConsoleColors(p.colorMode)}
+		if p.theme != nil {
+			opts = append(opts, ConsoleTheme(*p.theme))
+		}
+
+		return NewConsoleFormatter(w, opts...)
+	}
+
+	return JSONFormatter
+}
+
+func isFormatName(format string) bool {
+	format = strings.ToLower(format)
+	return format == "json" || format == "text" || format == "console"
+}
+
+func splitList(s string) []string {
+	list := make([]string, 0)
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+// LogWriter pipeline built from configuration
+type Pipeline struct {
+	config  Config
+	sinks   []pipelineSink
+	closers []io.Closer
+}
+
+type pipelineSink struct {
+	w        io.Writer
+	conf     LogWriterOption
+	settings []Setting
+}
+
+// Returns configuration pipeline was built from
+func (p *Pipeline) Config() Config {
+	return p.config
+}
+
+// Returns LogWriter that writes every record to all configured sinks
+// Settings are applied after configured ones
+func (p *Pipeline) LogWriter(ctx context.Context, settings ...Setting) io.Writer {
+	if len(p.sinks) == 1 {
+		sink := p.sinks[0]
+		return LogWriter(ctx, sink.w, sink.conf, append(sink.settings[:len(sink.settings):len(sink.settings)], settings...)...)
+	}
+
+	writers := make(multiWriter, len(p.sinks))
+	for i, sink := range p.sinks {
+		writers[i] = LogWriter(ctx, sink.w, sink.conf, append(sink.settings[:len(sink.settings):len(sink.settings)], settings...)...).(*logWriter)
+	}
+
+	return writers
+}
+
+// Closes file sinks
+func (p *Pipeline) Close() error {
+	var err error
+	for _, closer := range p.closers {
+		if cErr := closer.Close(); cErr != nil && err == nil {
+			err = cErr
+		}
+	}
+
+	p.closers = nil
+
+	return err
+}
+
+// Writes record to every LogWriter
+// Lazy tags are evaluated once per record
+// FatalHandlers run once after the record is written to all LogWriters
+type multiWriter []*logWriter
+
+func (w multiWriter) Write(p []byte) (int, error) {
+	var (
+		err      error
+		message  string
+		handlers []*FatalHandler
+		sinks    map[*FatalHandler][]io.Writer
+		cache    = make(lazyCache)
+	)
+
+	for _, writer := range w {
+		_, fatal, wErr := writer.write(p, cache)
+		if wErr != nil && err == nil {
+			err = wErr
+		}
+
+		if fatal == nil {
+			continue
+		}
+
+		if sinks == nil {
+			message, sinks = *fatal, make(map[*FatalHandler][]io.Writer)
+		}
+
+		if _, ok := sinks[writer.fatal]; !ok {
+			handlers = append(handlers, writer.fatal)
+		}
+
+		sinks[writer.fatal] = append(sinks[writer.fatal], writer.w)
+	}
+
+	for _, h := range handlers {
+		h.handle(message, sinks[h]...)
+	}
+
+	return len(p), err
+}
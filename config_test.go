@@ -0,0 +1,296 @@
+package logw_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/logwtest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestConfig(t *testing.T) {
+	suite.Run(t, new(configSuite))
+}
+
+type configSuite struct {
+	suite.Suite
+
+	dir string
+}
+
+func (s *configSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+
+	for _, env := range []string{
+		"LOGW_CONFIG", "LOGW_LEVEL", "LOGW_FORMAT", "LOGW_DATE_LAYOUT", "LOGW_TIME_ZONE", "LOGW_TIME_PRECISION",
+		"LOGW_COLOR", "LOGW_THEME", "LOGW_SINKS", "LOGW_REDACT", "LOGW_SERVICE", "LOGW_ENV", "LOGW_ENRICH",
+		"LOGW_SAMPLING_INITIAL", "LOGW_SAMPLING_THEREAFTER", "LOGW_SAMPLING_TICK",
+	} {
+		s.T().Setenv(env, "")
+		s.Require().NoError(os.Unsetenv(env))
+	}
+}
+
+func (s *configSuite) TestValidationListsEveryInvalidField() {
+	c := logw.Config{
+		Level:         "verbose",
+		TagLevel:      "quiet",
+		Format:        "xml",
+		DateLayout:    "yesterday",
+		TimeZone:      "Nowhere/Unknown",
+		TimePrecision: "fast",
+		Color:         "sometimes",
+		Theme:         "neon",
+		Sinks:         []logw.SinkConfig{{Output: "stdout"}, {Level: "loud", TagLevel: "all", Format: "yaml"}},
+		Sampling:      &logw.SamplingConfig{Initial: -1, Thereafter: -1, Tick: "often"},
+		Redact:        []string{"password", ""},
+	}
+
+	err := c.Validate()
+	s.Require().Error(err)
+
+	var configErr *logw.ConfigError
+	s.Require().True(errors.As(err, &configErr))
+
+	fields := make([]string, len(configErr.Fields))
+	for i, field := range configErr.Fields {
+		fields[i] = field.Field
+	}
+
+	s.Equal(
+		[]string{
+			"level", "tag_level", "format", "date_layout", "time_zone", "time_precision", "color", "theme",
+			"sinks[1].output", "sinks[1].level", "sinks[1].tag_level", "sinks[1].format",
+			"sampling.initial", "sampling.thereafter", "sampling.tick",
+			"redact[1]",
+		},
+		fields,
+	)
+	s.Contains(err.Error(), `level "verbose": unknown level`)
+
+	_, err = c.Build()
+	s.Equal(configErr, err)
+
+	err = logw.Config{Sampling: &logw.SamplingConfig{}}.Validate()
+	s.Require().Error(err)
+	s.Contains(err.Error(), `sampling.initial "0": initial and thereafter must not both be zero`)
+
+	err = logw.Config{Sampling: &logw.SamplingConfig{Initial: 1, Tick: "0s"}}.Validate()
+	s.Require().Error(err)
+	s.Contains(err.Error(), `sampling.tick "0s": must be positive`)
+
+	s.NoError(logw.Config{}.Validate())
+	s.NoError(logw.Config{DateLayout: "2006-01-02", TimeZone: "utc", Theme: "Solarized", Format: "Console"}.Validate())
+}
+
+func (s *configSuite) TestReadConfig() {
+	c, err := logw.ReadConfig(strings.NewReader(`{"level":"debug","sinks":[{"output":"stdout","format":"text"}]}`))
+	s.Require().NoError(err)
+
+	s.Equal(logw.Config{Level: "debug", Sinks: []logw.SinkConfig{{Output: "stdout", Format: "text"}}}, c)
+
+	_, err = logw.ReadConfig(strings.NewReader(`{"lvl":"debug"}`))
+	s.Require().Error(err)
+	s.Contains(err.Error(), "lvl")
+
+	_, err = logw.LoadConfig(filepath.Join(s.dir, "missing.json"))
+	s.Error(err)
+}
+
+func (s *configSuite) TestBuild() {
+	jsonPath, textPath := filepath.Join(s.dir, "archive.log"), filepath.Join(s.dir, "console.log")
+	c := logw.Config{
+		Level:      "debug",
+		DateLayout: "none",
+		Sinks: []logw.SinkConfig{
+			{Output: jsonPath},
+			{Output: textPath, Level: "warn", Format: "text"},
+		},
+		Redact: []string{"password"},
+	}
+
+	pipeline, err := c.Build()
+	s.Require().NoError(err)
+
+	s.Equal(c, pipeline.Config())
+
+	ctx := logw.AppendInfo(context.TODO(), "password", "secret")
+	log := log.New(pipeline.LogWriter(ctx), "", 0)
+
+	log.Println(logw.Debug, "debug")
+	log.Println(logw.Warn.WithString("user", "test"), "warn")
+
+	s.Require().NoError(pipeline.Close())
+
+	archive, err := os.ReadFile(jsonPath)
+	s.Require().NoError(err)
+
+	lines := strings.Split(strings.TrimSpace(string(archive)), "\n")
+	s.Require().Len(lines, 2)
+
+	record := make(map[string]any)
+	s.Require().NoError(json.Unmarshal([]byte(lines[1]), &record))
+
+	s.Equal("warn", record["level"])
+	s.Equal([]any{logw.RedactedValue}, record["password"])
+	s.Equal([]any{"test"}, record["user"])
+	s.NotContains(record, "date")
+
+	console, err := os.ReadFile(textPath)
+	s.Require().NoError(err)
+
+	s.NotContains(string(console), "debug")
+	s.Contains(string(console), `password:["[REDACTED]"]`)
+	s.Contains(string(console), "warn")
+}
+
+func (s *configSuite) TestSinkTagLevel() {
+	jsonPath, textPath := filepath.Join(s.dir, "archive.log"), filepath.Join(s.dir, "console.log")
+	c := logw.Config{
+		Level:      "debug",
+		TagLevel:   "info",
+		DateLayout: "none",
+		Sinks: []logw.SinkConfig{
+			{Output: jsonPath},
+			{Output: textPath, TagLevel: "trace", Format: "text"},
+		},
+	}
+
+	pipeline, err := c.Build()
+	s.Require().NoError(err)
+
+	ctx := logw.AppendDebug(context.TODO(), "query", "select 1")
+	ctx = logw.AppendInfo(ctx, "request_id", "42")
+
+	log.New(pipeline.LogWriter(ctx), "", 0).Println(logw.Info.WithString("user", "test"), "test")
+	s.Require().NoError(pipeline.Close())
+
+	archive, err := os.ReadFile(jsonPath)
+	s.Require().NoError(err)
+
+	record := make(map[string]any)
+	s.Require().NoError(json.Unmarshal(archive, &record))
+
+	s.NotContains(record, "query")
+	s.Equal([]any{"42"}, record["request_id"])
+	s.Equal([]any{"test"}, record["user"])
+
+	console, err := os.ReadFile(textPath)
+	s.Require().NoError(err)
+
+	s.Contains(string(console), `query:["select 1"]`)
+	s.Contains(string(console), `request_id:["42"]`)
+}
+
+func (s *configSuite) TestFatalWrittenToAllSinks() {
+	aPath, bPath := filepath.Join(s.dir, "a.log"), filepath.Join(s.dir, "b.log")
+	pipeline, err := logw.Config{Sinks: []logw.SinkConfig{{Output: aPath}, {Output: bPath}}}.Build()
+	s.Require().NoError(err)
+
+	recorder := new(logwtest.ExitRecorder)
+	h := logw.NewFatalHandler(1, time.Second)
+	h.Exit = recorder.Exit
+
+	log := log.New(pipeline.LogWriter(context.TODO(), logw.OnFatal(h)), "", 0)
+	log.Println(logw.Fatal, "test")
+	log.Println(logw.Info, "after")
+	s.Require().NoError(pipeline.Close())
+
+	for _, path := range []string{aPath, bPath} {
+		b, err := os.ReadFile(path)
+		s.Require().NoError(err)
+		s.Contains(string(b), `"level":"fatal"`)
+		s.Contains(string(b), `"message":"after"`)
+	}
+
+	s.Equal([]int{1}, recorder.Codes())
+}
+
+func (s *configSuite) TestBuildFailsToOpenSink() {
+	_, err := logw.Config{Sinks: []logw.SinkConfig{{Output: filepath.Join(s.dir, "missing", "app.log")}}}.Build()
+	s.Error(err)
+}
+
+func (s *configSuite) TestEnvConfig() {
+	path := filepath.Join(s.dir, "logw.json")
+	s.Require().NoError(os.WriteFile(path, []byte(`{"level":"warn","format":"text","redact":["token"]}`), 0o644))
+
+	s.T().Setenv("LOGW_CONFIG", path)
+	s.T().Setenv("LOGW_LEVEL", "debug")
+	s.T().Setenv("LOGW_TAG_LEVEL", "info")
+	s.T().Setenv("LOGW_SINKS", "stdout, "+filepath.Join(s.dir, "app.log"))
+	s.T().Setenv("LOGW_SAMPLING_INITIAL", "10")
+	s.T().Setenv("LOGW_SAMPLING_TICK", "1s")
+	s.T().Setenv("LOGW_SERVICE", "api")
+	s.T().Setenv("LOGW_ENRICH", "true")
+
+	c, err := logw.EnvConfig()
+	s.Require().NoError(err)
+
+	s.Equal(
+		logw.Config{
+			Level:    "debug",
+			TagLevel: "info",
+			Format:   "text",
+			Sinks:    []logw.SinkConfig{{Output: "stdout"}, {Output: filepath.Join(s.dir, "app.log")}},
+			Sampling: &logw.SamplingConfig{Initial: 10, Tick: "1s"},
+			Redact:   []string{"token"},
+			Enrich:   true,
+			Service:  "api",
+		},
+		c,
+	)
+
+	s.T().Setenv("LOGW_SAMPLING_THEREAFTER", "many")
+
+	_, err = logw.EnvConfig()
+	s.Require().Error(err)
+	s.Contains(err.Error(), `LOGW_SAMPLING_THEREAFTER "many": not an integer`)
+}
+
+func (s *configSuite) TestServiceTags() {
+	path := filepath.Join(s.dir, "app.log")
+	pipeline, err := logw.Config{Sinks: []logw.SinkConfig{{Output: path}}, Service: "api", Env: "prod"}.Build()
+	s.Require().NoError(err)
+
+	log.New(pipeline.LogWriter(context.TODO()), "", 0).Println(logw.Info, "test")
+	s.Require().NoError(pipeline.Close())
+
+	b, err := os.ReadFile(path)
+	s.Require().NoError(err)
+
+	record := make(map[string]any)
+	s.Require().NoError(json.Unmarshal(b, &record))
+
+	s.Equal([]any{"api"}, record["service"])
+	s.Equal([]any{"prod"}, record["env"])
+	s.NotContains(record, "pid")
+}
+
+func (s *configSuite) TestSampling() {
+	path := filepath.Join(s.dir, "app.log")
+	pipeline, err := logw.Config{
+		Sinks:    []logw.SinkConfig{{Output: path}},
+		Sampling: &logw.SamplingConfig{Initial: 2, Thereafter: 3, Tick: "1h"},
+	}.Build()
+	s.Require().NoError(err)
+
+	for i := 0; i < 10; i++ {
+		log.New(pipeline.LogWriter(context.TODO()), "", 0).Println(logw.Info, "repeated")
+	}
+
+	s.Require().NoError(pipeline.Close())
+
+	b, err := os.ReadFile(path)
+	s.Require().NoError(err)
+
+	s.Equal(4, strings.Count(string(b), "repeated"))
+}
@@ -1,7 +1,7 @@
 // This package provides simple structured logging.
 // logw.LogWriter(...) returns io.Writer that most of the existing loggers can consume.
 // It will transform input according to logw.Formatter you choose.
-// You can control log level by using provided logw.Debug|Info|Wran|Error|Fatal variables like this:
+// You can control log level by using provided logw.Trace|Debug|Info|Warn|Error|Fatal variables like this:
 // 	log.Println(logw.Error, "your message")
 // or like this:
 // 	log.Prinln(logw.Error.WithMessage("Hello %s", "World"))
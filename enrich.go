@@ -0,0 +1,79 @@
+package logw
+
+import (
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"sync"
+)
+
+var (
+	processTagsOnce sync.Once
+	processTags     []Tag
+)
+
+// Returns tags describing current process, computed once:
+// "hostname", "pid", "go_version" and, if available from build info, "version" of main module and "vcs_revision"
+func ProcessTags() []Tag {
+	processTagsOnce.Do(func() {
+		tag := func(key, value, valueType string) Tag {
+			return Tag{Key: key, Type: valueType, Value: []byte(value), Level: LevelInfo}
+		}
+
+		if hostname, err := os.Hostname(); err == nil {
+			processTags = append(processTags, tag("hostname", hostname, "string"))
+		}
+
+		processTags = append(
+			processTags,
+			tag("pid", strconv.Itoa(os.Getpid()), "int"),
+			tag("go_version", runtime.Version(), "string"),
+		)
+
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+
+		if info.Main.Version != "" {
+			processTags = append(processTags, tag("version", info.Main.Version, "string"))
+		}
+
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" && setting.Value != "" {
+				processTags = append(processTags, tag("vcs_revision", setting.Value, "string"))
+			}
+		}
+	})
+
+	return append([]Tag(nil), processTags...)
+}
+
+// Makes LogWriter add process tags (see ProcessTags) and "service" and "env" tags to every record
+// Empty service and env are omitted
+// Tags are computed once and added with Info level
+func Enrich(service, env string) Setting {
+	return addTags(append(ProcessTags(), serviceTags(service, env)...))
+}
+
+func serviceTags(service, env string) []Tag {
+	tags := make([]Tag, 0, 2)
+
+	if service != "" {
+		tags = append(tags, Tag{Key: "service", Type: "string", Value: []byte(service), Level: LevelInfo})
+	}
+
+	if env != "" {
+		tags = append(tags, Tag{Key: "env", Type: "string", Value: []byte(env), Level: LevelInfo})
+	}
+
+	return tags
+}
+
+func addTags(tags []Tag) Setting {
+	return Process(func(r *Record) bool {
+		r.Tags = append(r.Tags, tags...)
+		return true
+	})
+}
@@ -0,0 +1,89 @@
+package logw_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"runtime"
+	"testing"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestEnrich(t *testing.T) {
+	suite.Run(t, new(enrichSuite))
+}
+
+type enrichSuite struct {
+	suite.Suite
+}
+
+func (s *enrichSuite) TestProcessTags() {
+	tags := make(map[string]logw.Tag)
+	for _, tag := range logw.ProcessTags() {
+		tags[tag.Key] = tag
+	}
+
+	hostname, err := os.Hostname()
+	s.Require().NoError(err)
+
+	s.Equal(hostname, string(tags["hostname"].Value))
+	s.Equal("int", tags["pid"].Type)
+	s.Equal(runtime.Version(), string(tags["go_version"].Value))
+
+	s.Equal(logw.ProcessTags(), logw.ProcessTags())
+}
+
+func (s *enrichSuite) TestEnrich() {
+	b := new(bytes.Buffer)
+	log := log.New(
+		logw.LogWriter(
+			context.TODO(),
+			b,
+			logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter),
+			logw.Enrich("api", "prod"),
+		),
+		"",
+		0,
+	)
+
+	log.Println(logw.Info, "test")
+
+	record := make(map[string]any)
+	s.Require().NoError(json.Unmarshal(b.Bytes(), &record))
+
+	hostname, err := os.Hostname()
+	s.Require().NoError(err)
+
+	s.Equal([]any{hostname}, record["hostname"])
+	s.Equal([]any{float64(os.Getpid())}, record["pid"])
+	s.Equal([]any{runtime.Version()}, record["go_version"])
+	s.Equal([]any{"api"}, record["service"])
+	s.Equal([]any{"prod"}, record["env"])
+}
+
+func (s *enrichSuite) TestEnrichOmitsEmptyServiceAndEnv() {
+	b := new(bytes.Buffer)
+	log := log.New(
+		logw.LogWriter(
+			context.TODO(),
+			b,
+			logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter),
+			logw.Enrich("", ""),
+		),
+		"",
+		0,
+	)
+
+	log.Println(logw.Info, "test")
+
+	record := make(map[string]any)
+	s.Require().NoError(json.Unmarshal(b.Bytes(), &record))
+
+	s.Contains(record, "pid")
+	s.NotContains(record, "service")
+	s.NotContains(record, "env")
+}
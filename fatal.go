@@ -0,0 +1,116 @@
+package logw
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Default time given to Fatal hooks and sinks to finish
+const DefaultFatalTimeout = 5 * time.Second
+
+// Fatal level records handler
+// Runs registered hooks, flushes outputs the Fatal record was written to and terminates the program
+// Outputs are closed only before os.Exit, so they stay usable if Exit is replaced or Panic is set
+type FatalHandler struct {
+	// Exit code
+	Code int
+	// Time given to hooks and sinks to finish
+	Timeout time.Duration
+	// Panic instead of exiting
+	Panic bool
+	// Replaces os.Exit, useful in tests
+	Exit func(code int)
+
+	mu    sync.Mutex
+	hooks []func()
+}
+
+// FatalHandler constructor
+// Exits with provided code after all hooks have run and sinks are flushed or timeout has passed
+func NewFatalHandler(code int, timeout time.Duration) *FatalHandler {
+	return &FatalHandler{Code: code, Timeout: timeout}
+}
+
+// Registers hook that will run before program terminates
+func (h *FatalHandler) AddHook(hook func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.hooks = append(h.hooks, hook)
+}
+
+// Makes LogWriter run FatalHandler on every Fatal level record
+// LogWriter output will be flushed by FatalHandler after the record is written
+// Pipeline runs FatalHandler once after the record is written to all sinks
+func OnFatal(h *FatalHandler) Setting {
+	return func(w *logWriter) {
+		w.fatal = h
+	}
+}
+
+func (h *FatalHandler) handle(message string, sinks ...io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for _, hook := range h.hooks {
+			runHook(hook)
+		}
+
+		for _, sink := range sinks {
+			flush(sink, !h.Panic && h.Exit == nil)
+		}
+	}()
+
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = DefaultFatalTimeout
+	}
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	}
+
+	if h.Panic {
+		panic(fmt.Sprintf("fatal: %s", message))
+	}
+
+	if h.Exit != nil {
+		h.Exit(h.Code)
+		return
+	}
+
+	os.Exit(h.Code)
+}
+
+func runHook(hook func()) {
+	defer func() { _ = recover() }()
+
+	hook()
+}
+
+func flush(w io.Writer, close bool) {
+	_ = flushWriter(w)
+
+	if c, ok := w.(io.Closer); ok && close {
+		_ = c.Close()
+	}
+}
+
+func flushWriter(w io.Writer) error {
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		return f.Flush()
+	case interface{ Sync() error }:
+		return f.Sync()
+	}
+
+	return nil
+}
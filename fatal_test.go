@@ -0,0 +1,141 @@
+package logw_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/logwtest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestFatalHandler(t *testing.T) {
+	suite.Run(t, new(fatalHandlerSuite))
+}
+
+type fatalHandlerSuite struct {
+	suite.Suite
+}
+
+type flushBuffer struct {
+	bytes.Buffer
+
+	flushed bool
+	closed  bool
+}
+
+func (b *flushBuffer) Flush() error {
+	b.flushed = true
+	return nil
+}
+
+func (b *flushBuffer) Close() error {
+	b.closed = true
+	return nil
+}
+
+func (s *fatalHandlerSuite) TestNoExitBelowFatal() {
+	recorder := new(logwtest.ExitRecorder)
+	h := logw.NewFatalHandler(1, time.Second)
+	h.Exit = recorder.Exit
+
+	log := log.New(logw.JSONLogWriter(context.TODO(), new(bytes.Buffer), logw.OnFatal(h)), "", 0)
+	log.Println(logw.Error, "test")
+
+	s.False(recorder.Exited())
+}
+
+func (s *fatalHandlerSuite) TestExitOnFatal() {
+	b := new(flushBuffer)
+	recorder := new(logwtest.ExitRecorder)
+	h := logw.NewFatalHandler(3, time.Second)
+	h.Exit = recorder.Exit
+
+	hookCalled := false
+	h.AddHook(func() { hookCalled = true })
+
+	log := log.New(logw.JSONLogWriter(context.TODO(), b, logw.OnFatal(h)), "", 0)
+	log.Println(logw.Fatal, "test")
+
+	s.Contains(b.String(), "\"level\":\"fatal\"")
+	s.True(hookCalled)
+	s.True(b.flushed)
+	s.False(b.closed)
+	s.Equal([]int{3}, recorder.Codes())
+
+	log.Println(logw.Info, "after")
+	s.Contains(b.String(), "after")
+}
+
+func (s *fatalHandlerSuite) TestPanicOnFatal() {
+	b := new(flushBuffer)
+	h := logw.NewFatalHandler(1, time.Second)
+	h.Panic = true
+
+	log := log.New(logw.JSONLogWriter(context.TODO(), b, logw.OnFatal(h)), "", 0)
+
+	s.PanicsWithValue("fatal: test", func() { log.Println(logw.Fatal, "test") })
+	s.True(b.flushed)
+	s.False(b.closed)
+}
+
+func (s *fatalHandlerSuite) TestExitAfterTimeout() {
+	recorder := new(logwtest.ExitRecorder)
+	h := logw.NewFatalHandler(1, 10*time.Millisecond)
+	h.Exit = recorder.Exit
+	h.AddHook(func() { time.Sleep(time.Second) })
+
+	log := log.New(logw.JSONLogWriter(context.TODO(), new(bytes.Buffer), logw.OnFatal(h)), "", 0)
+
+	start := time.Now()
+	log.Println(logw.Fatal, "test")
+
+	s.Less(time.Since(start), time.Second)
+	s.Equal([]int{1}, recorder.Codes())
+}
+
+func (s *fatalHandlerSuite) TestHookPanicDoesNotPreventExit() {
+	recorder := new(logwtest.ExitRecorder)
+	h := logw.NewFatalHandler(2, time.Second)
+	h.Exit = recorder.Exit
+	h.AddHook(func() { panic("hook failed") })
+
+	log := log.New(logw.JSONLogWriter(context.TODO(), new(bytes.Buffer), logw.OnFatal(h)), "", 0)
+	log.Println(logw.Fatal, "test")
+
+	s.Equal([]int{2}, recorder.Codes())
+}
+
+func (s *fatalHandlerSuite) TestFlushesSyncWriterOutput() {
+	recorder := new(logwtest.ExitRecorder)
+	h := logw.NewFatalHandler(1, time.Second)
+	h.Exit = recorder.Exit
+
+	b := new(flushBuffer)
+	log := log.New(logw.JSONLogWriter(context.TODO(), logw.SyncWriter(b), logw.OnFatal(h)), "", 0)
+	log.Println(logw.Fatal, "test")
+
+	s.True(b.flushed)
+	s.False(b.closed)
+	s.Equal([]int{1}, recorder.Codes())
+}
+
+func (s *fatalHandlerSuite) TestFlushesOnlyWritingLogWriterOutput() {
+	recorder := new(logwtest.ExitRecorder)
+	h := logw.NewFatalHandler(1, time.Second)
+	h.Exit = recorder.Exit
+
+	other, b := new(flushBuffer), new(flushBuffer)
+	_ = logw.JSONLogWriter(context.TODO(), other, logw.OnFatal(h))
+
+	log := log.New(logw.JSONLogWriter(context.TODO(), b, logw.OnFatal(h)), "", 0)
+	log.Println(logw.Fatal, "test")
+
+	s.True(b.flushed)
+	s.False(other.flushed)
+	s.False(other.closed)
+	s.Equal([]int{1}, recorder.Codes())
+}
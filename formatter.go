@@ -3,6 +3,7 @@ package logw
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"strconv"
 	"strings"
 	"text/tabwriter"
@@ -26,7 +27,7 @@ type Formatter func(
 
 // JSON message formatter
 // Has format of:
-//  { "date": string|optional, "level": string, "levelCode": int, "message": string|optional }
+//  { "date": string|number|optional, "level": string, "levelCode": int, "message": string|optional }
 func JSONFormatter(
 	level string,
 	levelCode int,
@@ -47,13 +48,23 @@ func JSONFormatter(
 	sb.WriteByte('"')
 	sb.WriteString(level)
 	sb.WriteByte('"')
-	sb.WriteByte(',')
 
 	if dateLayout != NoDate {
+		date, isNumber := formatTimeStamp(timeStamp, dateLayout)
+		if !isNumber && timeStamp.Location() == time.Local {
+			date = timeStamp.UTC().Format(dateLayout)
+		}
+
+		sb.WriteByte(',')
 		sb.WriteString("\"date\":")
-		sb.WriteByte('"')
-		sb.WriteString(timeStamp.UTC().Format(dateLayout))
-		sb.WriteByte('"')
+
+		if isNumber {
+			sb.WriteString(date)
+		} else {
+			sb.WriteByte('"')
+			sb.WriteString(date)
+			sb.WriteByte('"')
+		}
 	}
 
 	if len(message) > 0 {
@@ -75,10 +86,9 @@ func JSONFormatter(
 	}
 
 	for k, v := range tagsMap {
+		key, _ := json.Marshal(k)
 		sb.WriteByte(',')
-		sb.WriteByte('"')
-		sb.WriteString(k)
-		sb.WriteByte('"')
+		sb.Write(key)
 		sb.WriteByte(':')
 		sb.WriteByte('[')
 		sb.Write(bytes.Join(v, []byte{','}))
@@ -101,9 +111,55 @@ func TextFormatter(
 	timeStamp time.Time,
 	dateLayout string,
 	message []byte,
+) []byte {
+	return formatText(defaultPalette, level, levelCode, tags, timeStamp, dateLayout, message)
+}
+
+// Returns text message formatter that uses colors of color.DefaultTheme according to mode
+// In color.Auto mode colors are used only if w is a terminal and environment allows colors
+func NewTextFormatter(w io.Writer, mode color.Mode) Formatter {
+	return NewThemedTextFormatter(w, mode, color.DefaultTheme)
+}
+
+// Returns text message formatter that uses colors of theme according to mode
+// Theme colors are degraded to color depth supported by terminal
+func NewThemedTextFormatter(w io.Writer, mode color.Mode, theme color.Theme) Formatter {
+	var palette *color.Palette
+	if color.Enabled(w, mode) {
+		palette = theme.Palette(color.DetectDepth())
+	}
+
+	return func(
+		level string,
+		levelCode int,
+		tags []Tag,
+		timeStamp time.Time,
+		dateLayout string,
+		message []byte,
+	) []byte {
+		return formatText(palette, level, levelCode, tags, timeStamp, dateLayout, message)
+	}
+}
+
+var defaultPalette = color.DefaultTheme.Palette(color.Depth16)
+
+func formatText(
+	palette *color.Palette,
+	level string,
+	levelCode int,
+	tags []Tag,
+	timeStamp time.Time,
+	dateLayout string,
+	message []byte,
 ) []byte {
 	var sb strings.Builder
-	levelColor := color.GetLevelColor(levelCode)
+	colorize := func(c color.Color, text string) string {
+		if palette == nil || c == "" {
+			return text
+		}
+
+		return color.ColorizeText(c, text)
+	}
 	adjust := func(s string) string {
 		if s == "info" || s == "warn" {
 			return " " + s
@@ -112,27 +168,38 @@ func TextFormatter(
 		return s
 	}
 
-	sb.WriteString(color.ColorizeText(levelColor, adjust(level)))
+	levelColor := color.Color("")
+	timeStampColor := color.Color("")
+	tagKeyColor := color.Color("")
+	messageColor := color.Color("")
+	if palette != nil {
+		levelColor = palette.Level(levelCode)
+		timeStampColor = palette.TimeStamp
+		tagKeyColor = palette.TagKey
+		messageColor = palette.Message
+	}
+
+	sb.WriteString(colorize(levelColor, adjust(level)))
 	sb.WriteByte('\t')
 
 	if dateLayout != NoDate {
-		sb.WriteString(color.ColorizeText(color.ANSIColorGray, timeStamp.Format(dateLayout)))
+		date, _ := formatTimeStamp(timeStamp, dateLayout)
+		sb.WriteString(colorize(timeStampColor, date))
 		sb.WriteByte('\t')
 	}
 
 	tagsMap := make(map[string][][]byte)
 	for _, tag := range tags {
-		if tag.Type != "string" {
-			tagsMap[tag.Key] = append(tagsMap[tag.Key], tag.Value)
-			continue
+		v := string(tag.Value)
+		if tag.Type == "string" {
+			v = strconv.Quote(v)
 		}
 
-		v := "\"" + string(tag.Value) + "\""
-		tagsMap[tag.Key] = append(tagsMap[tag.Key], []byte(v))
+		tagsMap[tag.Key] = append(tagsMap[tag.Key], []byte(colorize(tagColor(palette, tag), v)))
 	}
 
 	for k, values := range tagsMap {
-		sb.WriteString(k)
+		sb.WriteString(colorize(tagKeyColor, textKey(k)))
 		sb.WriteByte(':')
 		sb.WriteByte('[')
 		sb.Write(bytes.Join(values, []byte{','}))
@@ -141,20 +208,64 @@ func TextFormatter(
 		sb.WriteByte('\t')
 	}
 
-	sb.Write(message)
+	// only the first message line is aligned, continuation lines are written as is
+	firstLine, continuation := message, []byte(nil)
+	if i := bytes.IndexByte(message, '\n'); i != -1 {
+		firstLine, continuation = message[:i], message[i+1:]
+	}
+
+	if len(firstLine) > 0 {
+		sb.WriteString(colorize(messageColor, string(firstLine)))
+	}
+
 	sb.WriteByte('\n')
 
-	result := sb.String()
+	result := []byte(sb.String())
 	buf := new(bytes.Buffer)
 	w := tabwriter.NewWriter(buf, 0, 2, 2, ' ', 0)
 
-	if _, err := w.Write([]byte(result)); err != nil {
-		return []byte(result)
+	if _, err := w.Write(result); err == nil && w.Flush() == nil {
+		result = buf.Bytes()
+	}
+
+	if continuation == nil {
+		return result
+	}
+
+	result = append(result, colorize(messageColor, string(continuation))...)
+
+	return append(result, '\n')
+}
+
+func tagColor(palette *color.Palette, tag Tag) color.Color {
+	if palette == nil {
+		return ""
 	}
 
-	if err := w.Flush(); err != nil {
-		return []byte(result)
+	switch tag.Type {
+	case "string":
+		if tag.Key == "error" {
+			return palette.Error
+		}
+
+		return palette.String
+	case "int", "float64":
+		return palette.Number
+	case "bool":
+		return palette.Bool
+	case "json":
+		return palette.JSON
+	}
+
+	return ""
+}
+
+// Returns tag key quoted if it is empty or contains characters that need escaping,
+// spaces or separators of text and console formatters
+func textKey(key string) string {
+	if quoted := strconv.Quote(key); key == "" || quoted[1:len(quoted)-1] != key || strings.ContainsAny(key, " =:") {
+		return quoted
 	}
 
-	return buf.Bytes()
+	return key
 }
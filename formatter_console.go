@@ -0,0 +1,234 @@
+package logw
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/andriiyaremenko/logwriter/color"
+)
+
+// Default maximum length of tag value printed on the message line
+const DefaultConsoleMaxValueLength = 80
+
+const consoleIndent = "    "
+
+// Console formatter option
+type ConsoleOption func(*consoleFormatter)
+
+// Prints time passed since formatter creation instead of time-stamp
+func RelativeTime() ConsoleOption {
+	return func(f *consoleFormatter) {
+		f.relative = true
+	}
+}
+
+// Truncates tag values printed on the message line to maxLength runes
+// Zero or negative maxLength disables truncation
+func MaxValueLength(maxLength int) ConsoleOption {
+	return func(f *consoleFormatter) {
+		f.maxValueLength = maxLength
+	}
+}
+
+// Sets console formatter color theme
+func ConsoleTheme(theme color.Theme) ConsoleOption {
+	return func(f *consoleFormatter) {
+		f.theme = theme
+	}
+}
+
+// Sets console formatter color mode
+func ConsoleColors(mode color.Mode) ConsoleOption {
+	return func(f *consoleFormatter) {
+		f.mode = mode
+	}
+}
+
+// Developer console message formatter
+// Has format of:
+//  level  ?time-stamp  message  tag-key=tag-value
+//      error-tag-key: error
+//      json-tag-key: {
+//        pretty-printed JSON
+//      }
+// Level column and relative time-stamp column have the same width in every record
+// Level column fits the longest level name registered before the call
+// JSON tag values, error tags and multi-line values are printed on indented lines after the message
+// By default uses colors of color.DarkTheme if w is a terminal and environment allows colors
+func NewConsoleFormatter(w io.Writer, opts ...ConsoleOption) Formatter {
+	f := &consoleFormatter{
+		start:          time.Now(),
+		theme:          color.DarkTheme,
+		mode:           color.Auto,
+		maxValueLength: DefaultConsoleMaxValueLength,
+		levelWidth:     levelNameWidth(),
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	if color.Enabled(w, f.mode) {
+		f.palette = f.theme.Palette(color.DetectDepth())
+	}
+
+	return f.format
+}
+
+type consoleFormatter struct {
+	start          time.Time
+	theme          color.Theme
+	mode           color.Mode
+	palette        *color.Palette
+	relative       bool
+	maxValueLength int
+	levelWidth     int
+}
+
+func (f *consoleFormatter) format(
+	level string,
+	levelCode int,
+	tags []Tag,
+	timeStamp time.Time,
+	dateLayout string,
+	message []byte,
+) []byte {
+	var sb strings.Builder
+
+	levelColor := color.Color("")
+	timeStampColor := color.Color("")
+	tagKeyColor := color.Color("")
+	messageColor := color.Color("")
+	if f.palette != nil {
+		levelColor = f.palette.Level(levelCode)
+		timeStampColor = f.palette.TimeStamp
+		tagKeyColor = f.palette.TagKey
+		messageColor = f.palette.Message
+	}
+
+	sb.WriteString(f.colorize(levelColor, padRight(level, f.levelWidth)))
+	sb.WriteString("  ")
+
+	if dateLayout != NoDate {
+		sb.WriteString(f.colorize(timeStampColor, f.timeStamp(timeStamp, dateLayout)))
+		sb.WriteString("  ")
+	}
+
+	lines := strings.Split(string(message), "\n")
+	sb.WriteString(f.colorize(messageColor, lines[0]))
+
+	blocks := make([]Tag, 0)
+	for _, tag := range tags {
+		if isConsoleBlock(tag) {
+			blocks = append(blocks, tag)
+			continue
+		}
+
+		sb.WriteByte(' ')
+		sb.WriteString(f.colorize(tagKeyColor, textKey(tag.Key)))
+		sb.WriteByte('=')
+		sb.WriteString(f.colorize(tagColor(f.palette, tag), f.truncate(consoleValue(tag))))
+	}
+
+	for _, line := range lines[1:] {
+		sb.WriteByte('\n')
+		sb.WriteString(consoleIndent)
+		sb.WriteString(f.colorize(messageColor, line))
+	}
+
+	for _, tag := range blocks {
+		value := string(tag.Value)
+		if tag.Type == "json" {
+			buf := new(bytes.Buffer)
+			if err := json.Indent(buf, tag.Value, consoleIndent, "  "); err == nil {
+				value = buf.String()
+			}
+		}
+
+		sb.WriteByte('\n')
+		sb.WriteString(consoleIndent)
+		sb.WriteString(f.colorize(tagKeyColor, textKey(tag.Key)))
+		sb.WriteString(": ")
+
+		for i, line := range strings.Split(value, "\n") {
+			if i > 0 {
+				sb.WriteByte('\n')
+
+				if tag.Type != "json" {
+					sb.WriteString(consoleIndent + "  ")
+				}
+			}
+
+			sb.WriteString(f.colorize(tagColor(f.palette, tag), line))
+		}
+	}
+
+	sb.WriteByte('\n')
+
+	return []byte(sb.String())
+}
+
+func (f *consoleFormatter) timeStamp(timeStamp time.Time, dateLayout string) string {
+	if !f.relative {
+		date, _ := formatTimeStamp(timeStamp, dateLayout)
+		return date
+	}
+
+	return fmt.Sprintf("+%9.3fs", timeStamp.Sub(f.start).Seconds())
+}
+
+func (f *consoleFormatter) truncate(value string) string {
+	if f.maxValueLength <= 0 || utf8.RuneCountInString(value) <= f.maxValueLength {
+		return value
+	}
+
+	return string([]rune(value)[:f.maxValueLength]) + "…"
+}
+
+func (f *consoleFormatter) colorize(c color.Color, text string) string {
+	if c == "" {
+		return text
+	}
+
+	return color.ColorizeText(c, text)
+}
+
+func isConsoleBlock(tag Tag) bool {
+	if tag.Key == "error" || bytes.ContainsRune(tag.Value, '\n') {
+		return true
+	}
+
+	if tag.Type != "json" {
+		return false
+	}
+
+	value := bytes.TrimSpace(tag.Value)
+	return len(value) > 0 && (value[0] == '{' || value[0] == '[') && len(value) > 2
+}
+
+func consoleValue(tag Tag) string {
+	value := string(tag.Value)
+	if tag.Type != "string" {
+		return value
+	}
+
+	if value == "" || strings.ContainsAny(value, " \t\"=\\") || !utf8.ValidString(value) {
+		return strconv.Quote(value)
+	}
+
+	return value
+}
+
+func padRight(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+
+	return s
+}
@@ -0,0 +1,138 @@
+package logw_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/color"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestConsoleFormatter(t *testing.T) {
+	suite.Run(t, new(consoleFormatterSuite))
+}
+
+type consoleFormatterSuite struct {
+	suite.Suite
+}
+
+func (s *consoleFormatterSuite) TestAlignsColumns() {
+	b := new(bytes.Buffer)
+	format := logw.NewConsoleFormatter(b)
+	log := log.New(
+		logw.LogWriter(
+			context.TODO(),
+			b,
+			logw.Option(logw.LevelDebug, format, time.RFC3339),
+		),
+		"",
+		0,
+	)
+
+	log.Println(logw.Info.WithString("user", "test").WithInt("count", 2), "first")
+	log.Println(logw.Debug.WithString("name", "with space"), "second")
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	s.Require().Len(lines, 2)
+
+	s.True(strings.HasPrefix(lines[0], s.level(format, "info")+"  "))
+	s.True(strings.HasPrefix(lines[1], s.level(format, "debug")+"  "))
+	s.Equal(strings.Index(lines[0], "first"), strings.Index(lines[1], "second"))
+	s.True(strings.HasSuffix(lines[0], "first count=2 user=test"))
+	s.True(strings.HasSuffix(lines[1], "second name=\"with space\""))
+}
+
+func (s *consoleFormatterSuite) TestBlockValues() {
+	format := logw.NewConsoleFormatter(new(bytes.Buffer))
+	tags := []logw.Tag{
+		{Key: "user", Value: []byte("test"), Type: "string"},
+		{Key: "payload", Value: []byte(`{"id":1,"roles":["admin"]}`), Type: "json"},
+		{Key: "error", Value: []byte("failed:\nnot found"), Type: "string"},
+	}
+
+	b := format("error", 4, tags, time.Now(), logw.NoDate, []byte("request failed"))
+
+	s.Equal(
+		s.level(format, "error")+"  request failed user=test\n"+
+			"    payload: {\n"+
+			"      \"id\": 1,\n"+
+			"      \"roles\": [\n"+
+			"        \"admin\"\n"+
+			"      ]\n"+
+			"    }\n"+
+			"    error: failed:\n"+
+			"      not found\n",
+		string(b),
+	)
+}
+
+func (s *consoleFormatterSuite) TestQuotesTagKeys() {
+	format := logw.NewConsoleFormatter(new(bytes.Buffer))
+	tags := []logw.Tag{
+		{Key: "with space", Value: []byte("1"), Type: "int"},
+		{Key: "a=b", Value: []byte("2"), Type: "int"},
+		{Key: "line\nbreak", Value: []byte("failed:\nnot found"), Type: "string"},
+	}
+
+	b := format("info", 2, tags, time.Now(), logw.NoDate, []byte("test"))
+
+	s.Equal(
+		s.level(format, "info")+"  test \"with space\"=1 \"a=b\"=2\n"+
+			"    \"line\\nbreak\": failed:\n"+
+			"      not found\n",
+		string(b),
+	)
+}
+
+func (s *consoleFormatterSuite) TestTruncatesValues() {
+	format := logw.NewConsoleFormatter(new(bytes.Buffer), logw.MaxValueLength(5))
+	tags := []logw.Tag{
+		{Key: "short", Value: []byte("test"), Type: "string"},
+		{Key: "long", Value: []byte("тестовий"), Type: "string"},
+	}
+
+	b := format("info", 2, tags, time.Now(), logw.NoDate, []byte("test"))
+
+	s.Equal(s.level(format, "info")+"  test short=test long=тесто…\n", string(b))
+}
+
+func (s *consoleFormatterSuite) TestRelativeTime() {
+	start := time.Now()
+	format := logw.NewConsoleFormatter(new(bytes.Buffer), logw.RelativeTime())
+
+	b := format("warn", 3, nil, start.Add(1500*time.Millisecond), time.RFC3339, []byte("test"))
+
+	s.Regexp(`^`+s.level(format, "warn")+`  \+    1\.\d{3}s  test\n$`, string(b))
+}
+
+func (s *consoleFormatterSuite) TestColors() {
+	format := logw.NewConsoleFormatter(
+		new(bytes.Buffer),
+		logw.ConsoleColors(color.Always),
+		logw.ConsoleTheme(color.HighContrastTheme),
+	)
+	tags := []logw.Tag{{Key: "count", Value: []byte("1"), Type: "int"}}
+
+	b := format("info", 2, tags, time.Now(), logw.NoDate, []byte("test"))
+	palette := color.HighContrastTheme.Palette(color.Depth16)
+
+	s.Contains(string(b), color.ColorizeText(palette.Level(2), s.level(format, "info")))
+	s.Contains(string(b), color.ColorizeText(palette.TagKey, "count"))
+	s.Contains(string(b), color.ColorizeText(palette.Number, "1"))
+
+	plain := logw.NewConsoleFormatter(new(bytes.Buffer))("info", 2, tags, time.Now(), logw.NoDate, []byte("test"))
+	s.Equal(string(plain), color.ClearColors(string(b)))
+}
+
+// Returns level name padded to the width of format level column
+func (s *consoleFormatterSuite) level(format logw.Formatter, level string) string {
+	b := color.ClearColors(string(format("info", logw.LevelInfo, nil, time.Now(), logw.NoDate, []byte("|"))))
+	width := strings.Index(b, "|") - len("  ")
+
+	return level + strings.Repeat(" ", width-len(level))
+}
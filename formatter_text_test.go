@@ -0,0 +1,103 @@
+package logw_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/color"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestTextFormatter(t *testing.T) {
+	suite.Run(t, new(textFormatterSuite))
+}
+
+type textFormatterSuite struct {
+	suite.Suite
+}
+
+func (s *textFormatterSuite) TestColorsByDefault() {
+	b := logw.TextFormatter("warn", 3, nil, time.Now(), logw.NoDate, []byte("test"))
+
+	s.Equal(color.ColorizeText(color.ColorWarn, " warn")+"  test\n", string(b))
+}
+
+func (s *textFormatterSuite) TestColorModes() {
+	b := new(bytes.Buffer)
+
+	colored := logw.NewTextFormatter(b, color.Always)("warn", 3, nil, time.Now(), logw.NoDate, []byte("test"))
+	s.Equal(color.ColorizeText(color.ColorWarn, " warn")+"  test\n", string(colored))
+
+	plain := logw.NewTextFormatter(b, color.Never)("warn", 3, nil, time.Now(), logw.NoDate, []byte("test"))
+	s.Equal(" warn  test\n", string(plain))
+}
+
+func (s *textFormatterSuite) TestThemedTextFormatter() {
+	s.T().Setenv("COLORTERM", "")
+	s.T().Setenv("TERM", "xterm-256color")
+
+	theme := color.Theme{
+		Levels:  map[int]color.Style{3: {Foreground: color.RGB(255, 0, 0)}},
+		TagKey:  color.Style{Bold: true},
+		String:  color.Style{Foreground: color.Basic(2)},
+		Number:  color.Style{Foreground: color.Indexed(141)},
+		Error:   color.Style{Foreground: color.Basic(1)},
+		Message: color.Style{Underline: true},
+	}
+	tags := []logw.Tag{{Key: "count", Value: []byte("1"), Type: "int"}}
+	b := logw.NewThemedTextFormatter(new(bytes.Buffer), color.Always, theme)(
+		"warn", 3, tags, time.Now(), logw.NoDate, []byte("test"),
+	)
+
+	s.Equal(
+		color.ColorizeText("\033[38;5;196m", " warn")+"  "+
+			color.ColorizeText(color.ANSIFontBold, "count")+":["+color.ColorizeText("\033[38;5;141m", "1")+"]  "+
+			color.ColorizeText("\033[4m", "test")+"\n",
+		string(b),
+	)
+
+	tags = []logw.Tag{{Key: "error", Value: []byte("failed"), Type: "string"}}
+	b = logw.NewThemedTextFormatter(new(bytes.Buffer), color.Always, theme)(
+		"error", 4, tags, time.Now(), logw.NoDate, nil,
+	)
+
+	s.Contains(string(b), color.ColorizeText(color.ANSIColorRed, "\"failed\""))
+
+	b = logw.NewThemedTextFormatter(new(bytes.Buffer), color.Never, color.DarkTheme)(
+		"warn", 3, tags, time.Now(), logw.NoDate, []byte("test"),
+	)
+
+	s.Equal(" warn  error:[\"failed\"]  test\n", string(b))
+}
+
+func (s *textFormatterSuite) TestTextLogWriterWithoutTerminal() {
+	s.T().Setenv("FORCE_COLOR", "")
+	s.Require().NoError(os.Unsetenv("FORCE_COLOR"))
+	s.T().Setenv("CLICOLOR_FORCE", "")
+	s.Require().NoError(os.Unsetenv("CLICOLOR_FORCE"))
+
+	b := new(bytes.Buffer)
+	log := log.New(logw.TextLogWriter(context.TODO(), b), "", 0)
+
+	log.Println(logw.Error, "test")
+
+	s.Equal(b.String(), color.ClearColors(b.String()))
+	s.Contains(b.String(), "error")
+}
+
+func (s *textFormatterSuite) TestTextLogWriterForceColor() {
+	s.T().Setenv("NO_COLOR", "")
+	s.T().Setenv("FORCE_COLOR", "1")
+
+	b := new(bytes.Buffer)
+	log := log.New(logw.TextLogWriter(context.TODO(), b), "", 0)
+
+	log.Println(logw.Error, "test")
+
+	s.Contains(b.String(), color.ColorizeText(color.ColorError, "error"))
+}
@@ -0,0 +1,189 @@
+package logw
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// Field names of JSON records produced by other logging libraries
+type JSONSchema struct {
+	// Level field name
+	LevelKey string
+	// Message field name
+	MessageKey string
+	// Time-stamp field name
+	TimeKey string
+	// Numeric level values mapped to logw level codes
+	// Numeric levels missing in the map are logged with Info level
+	NumericLevels map[int]int
+}
+
+var (
+	// go.uber.org/zap production encoder schema
+	ZapSchema = JSONSchema{LevelKey: "level", MessageKey: "msg", TimeKey: "ts"}
+	// github.com/rs/zerolog schema
+	ZerologSchema = JSONSchema{LevelKey: "level", MessageKey: "message", TimeKey: "time"}
+	// github.com/sirupsen/logrus JSONFormatter schema
+	LogrusSchema = JSONSchema{LevelKey: "level", MessageKey: "msg", TimeKey: "time"}
+)
+
+// Tag key for time-stamps of ingested records
+const TimeTagKey = "time"
+
+// Returns writer that converts JSON records written by other libraries to logw records and writes them to w
+// Schema is chosen by the first one whose message field (or level field if none matched) is present in the record
+// If no schemas provided ZapSchema, ZerologSchema and LogrusSchema are used
+// Record level is mapped to logw level, message to logw message,
+// time-stamp to "time" tag in RFC3339Nano format and all other fields to tags
+// Fatal levels are logged with Error level and "original_level" tag, so ingested records never trigger FatalHandler
+// Writes that are not JSON objects are written to w as is
+// Expects one record per Write call, use LineWriter to split output on lines
+func JSONInput(w io.Writer, schemas ...JSONSchema) io.Writer {
+	if len(schemas) == 0 {
+		schemas = []JSONSchema{ZapSchema, ZerologSchema, LogrusSchema}
+	}
+
+	return &jsonInput{w: w, schemas: schemas}
+}
+
+type jsonInput struct {
+	w       io.Writer
+	schemas []JSONSchema
+}
+
+func (in *jsonInput) Write(p []byte) (int, error) {
+	record, ok := in.convert(p)
+	if !ok {
+		return in.w.Write(p)
+	}
+
+	if _, err := in.w.Write([]byte(record)); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (in *jsonInput) convert(p []byte) (string, bool) {
+	trimmed := bytes.TrimSpace(p)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return "", false
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(trimmed, &fields); err != nil {
+		return "", false
+	}
+
+	schema, ok := in.schema(fields)
+	if !ok {
+		return "", false
+	}
+
+	level := LevelInfo
+	if raw, ok := fields[schema.LevelKey]; ok {
+		level = parseJSONLevel(raw, schema.NumericLevels)
+		delete(fields, schema.LevelKey)
+	}
+
+	level, originalLevel := ingestedLevel(level)
+
+	var message string
+	if raw, ok := fields[schema.MessageKey]; ok {
+		if err := json.Unmarshal(raw, &message); err != nil {
+			message = string(raw)
+		}
+
+		delete(fields, schema.MessageKey)
+	}
+
+	logLevel := Level(level)
+
+	if originalLevel != "" {
+		logLevel = logLevel.WithString(OriginalLevelTagKey, originalLevel)
+	}
+
+	if raw, ok := fields[schema.TimeKey]; ok {
+		if timeStamp, ok := parseJSONTime(raw); ok {
+			delete(fields, schema.TimeKey)
+			logLevel = logLevel.WithString(TimeTagKey, timeStamp.Format(time.RFC3339Nano))
+		}
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+
+	for _, key := range keys {
+		var value string
+		if err := json.Unmarshal(fields[key], &value); err == nil {
+			logLevel = logLevel.WithString(key, value)
+			continue
+		}
+
+		logLevel = logLevel.appendTag(key, string(fields[key]), "json")
+	}
+
+	return logLevel.WithMessage("%s", message), true
+}
+
+func (in *jsonInput) schema(fields map[string]json.RawMessage) (JSONSchema, bool) {
+	for _, schema := range in.schemas {
+		if _, ok := fields[schema.MessageKey]; ok {
+			return schema, true
+		}
+	}
+
+	for _, schema := range in.schemas {
+		if _, ok := fields[schema.LevelKey]; ok {
+			return schema, true
+		}
+	}
+
+	return JSONSchema{}, false
+}
+
+func parseJSONLevel(raw json.RawMessage, numericLevels map[int]int) int {
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		if level, ok := levelFromAlias(name); ok {
+			return level
+		}
+
+		return LevelInfo
+	}
+
+	// numeric levels of different libraries are not compatible with each other
+	// so they are mapped only by schema
+	var code int
+	if err := json.Unmarshal(raw, &code); err == nil {
+		if level, ok := numericLevels[code]; ok {
+			return level
+		}
+	}
+
+	return LevelInfo
+}
+
+func parseJSONTime(raw json.RawMessage) (time.Time, bool) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		return t, err == nil
+	}
+
+	var epoch float64
+	if err := json.Unmarshal(raw, &epoch); err != nil {
+		return time.Time{}, false
+	}
+
+	sec, frac := math.Modf(epoch)
+	return time.Unix(int64(sec), int64(frac*float64(time.Second))).UTC(), true
+}
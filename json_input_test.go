@@ -0,0 +1,163 @@
+package logw_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/logwtest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestJSONInput(t *testing.T) {
+	suite.Run(t, new(jsonInputSuite))
+}
+
+type jsonInputSuite struct {
+	suite.Suite
+}
+
+func (s *jsonInputSuite) TestZap() {
+	b := new(bytes.Buffer)
+	w := logw.JSONInput(logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelDebug, logw.JSONFormatter)))
+
+	fmt.Fprintln(w, `{"level":"warn","ts":1650000000.5,"caller":"main.go:12","msg":"slow request","latency":1.5,"ok":false}`)
+
+	s.JSONEq(
+		"{\"levelCode\":3,\"level\":\"warn\",\"message\":\"slow request\","+
+			"\"caller\":[\"main.go:12\"],\"latency\":[1.5],\"ok\":[false],\"time\":[\"2022-04-15T05:20:00.5Z\"]}\n",
+		b.String(),
+	)
+}
+
+func (s *jsonInputSuite) TestZerolog() {
+	b := new(bytes.Buffer)
+	w := logw.JSONInput(logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelDebug, logw.JSONFormatter)))
+
+	fmt.Fprintln(w, `{"level":"error","error":"boom","user":{"id":1},"time":"2022-04-15T05:20:00Z","message":"failed"}`)
+
+	s.JSONEq(
+		"{\"levelCode\":4,\"level\":\"error\",\"message\":\"failed\","+
+			"\"error\":[\"boom\"],\"time\":[\"2022-04-15T05:20:00Z\"],\"user\":[{\"id\":1}]}\n",
+		b.String(),
+	)
+}
+
+func (s *jsonInputSuite) TestLogrus() {
+	b := new(bytes.Buffer)
+	w := logw.JSONInput(logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelDebug, logw.JSONFormatter)))
+
+	fmt.Fprintln(w, `{"level":"warning","msg":"careful","time":"2022-04-15T08:20:00+03:00"}`)
+
+	s.Equal(
+		"{\"levelCode\":3,\"level\":\"warn\",\"message\":\"careful\",\"time\":[\"2022-04-15T08:20:00+03:00\"]}\n",
+		b.String(),
+	)
+}
+
+func (s *jsonInputSuite) TestCustomSchema() {
+	b := new(bytes.Buffer)
+	schema := logw.JSONSchema{LevelKey: "severity", MessageKey: "text", TimeKey: "timestamp"}
+	w := logw.JSONInput(logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelDebug, logw.JSONFormatter)), schema)
+
+	fmt.Fprintln(w, `{"severity":"debug","text":"hello"}`)
+
+	s.Equal("{\"levelCode\":1,\"level\":\"debug\",\"message\":\"hello\"}\n", b.String())
+}
+
+func (s *jsonInputSuite) TestNumericLevels() {
+	b := new(bytes.Buffer)
+	pino := logw.JSONSchema{
+		LevelKey:      "level",
+		MessageKey:    "msg",
+		NumericLevels: map[int]int{30: logw.LevelInfo, 50: logw.LevelError},
+	}
+	w := logw.JSONInput(logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelDebug, logw.JSONFormatter)), pino)
+
+	fmt.Fprintln(w, `{"level":30,"msg":"first"}`)
+	fmt.Fprintln(w, `{"level":50,"msg":"second"}`)
+	fmt.Fprintln(w, `{"level":5,"msg":"third"}`)
+
+	s.Equal(
+		"{\"levelCode\":2,\"level\":\"info\",\"message\":\"first\"}\n"+
+			"{\"levelCode\":4,\"level\":\"error\",\"message\":\"second\"}\n"+
+			"{\"levelCode\":2,\"level\":\"info\",\"message\":\"third\"}\n",
+		b.String(),
+	)
+}
+
+func (s *jsonInputSuite) TestFatalDoesNotTriggerFatalHandler() {
+	recorder := new(logwtest.ExitRecorder)
+	h := logw.NewFatalHandler(1, time.Second)
+	h.Exit = recorder.Exit
+
+	b := new(bytes.Buffer)
+	schema := logw.JSONSchema{LevelKey: "level", MessageKey: "msg", NumericLevels: map[int]int{60: logw.LevelFatal}}
+	w := logw.JSONInput(
+		logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelDebug, logw.JSONFormatter), logw.OnFatal(h)),
+		schema,
+	)
+
+	fmt.Fprintln(w, `{"level":"fatal","msg":"first"}`)
+	fmt.Fprintln(w, `{"level":"emerg","msg":"second"}`)
+	fmt.Fprintln(w, `{"level":60,"msg":"third"}`)
+
+	s.Equal(
+		"{\"levelCode\":4,\"level\":\"error\",\"message\":\"first\",\"original_level\":[\"fatal\"]}\n"+
+			"{\"levelCode\":4,\"level\":\"error\",\"message\":\"second\",\"original_level\":[\"fatal\"]}\n"+
+			"{\"levelCode\":4,\"level\":\"error\",\"message\":\"third\",\"original_level\":[\"fatal\"]}\n",
+		b.String(),
+	)
+	s.False(recorder.Exited())
+}
+
+func (s *jsonInputSuite) TestLevelFiltering() {
+	b := new(bytes.Buffer)
+	w := logw.JSONInput(logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter)))
+
+	fmt.Fprintln(w, `{"level":"debug","msg":"hidden"}`)
+
+	s.Empty(b.String())
+}
+
+func (s *jsonInputSuite) TestPassesThroughOtherRecords() {
+	b := new(bytes.Buffer)
+	log := log.New(
+		logw.JSONInput(logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelDebug, logw.JSONFormatter))),
+		"",
+		0,
+	)
+
+	log.Println(logw.Error, "plain")
+	log.Println("{not json")
+	log.Println(`{"unknown":"schema"}`)
+
+	s.Equal(
+		"{\"levelCode\":4,\"level\":\"error\",\"message\":\"plain\"}\n"+
+			"{\"levelCode\":2,\"level\":\"info\",\"message\":\"{not json\"}\n"+
+			"{\"levelCode\":2,\"level\":\"info\",\"message\":\"{\\\"unknown\\\":\\\"schema\\\"}\"}\n",
+		b.String(),
+	)
+}
+
+func (s *jsonInputSuite) TestWithLineWriter() {
+	b := new(bytes.Buffer)
+	w := logw.LineWriter(
+		logw.JSONInput(logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelDebug, logw.JSONFormatter))),
+		0,
+		time.Second,
+	)
+
+	fmt.Fprint(w, `{"level":"info","msg":"first"}`+"\n"+`{"level":"error","msg":"second"}`+"\n")
+	s.NoError(w.Close())
+
+	s.Equal(
+		"{\"levelCode\":2,\"level\":\"info\",\"message\":\"first\"}\n"+
+			"{\"levelCode\":4,\"level\":\"error\",\"message\":\"second\"}\n",
+		b.String(),
+	)
+}
@@ -0,0 +1,80 @@
+package logw
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Tag value computed only when record with the tag is written
+// Context tags with Valuer values are evaluated after sampling and processors,
+// once per record even if Pipeline writes it to several sinks
+// Processors see lazy tags with nil Value
+type Valuer interface {
+	LogValue() any
+}
+
+// Function adapter for Valuer
+type ValuerFunc func() any
+
+// Returns function result
+func (f ValuerFunc) LogValue() any {
+	return f()
+}
+
+type lazyValue struct {
+	valuer Valuer
+}
+
+// Evaluated lazy tag values of one record
+type lazyCache map[*lazyValue]Tag
+
+// Replaces lazy tags with evaluated ones in place
+// cache may be nil
+func resolveTags(tags []Tag, cache lazyCache) {
+	for i, tag := range tags {
+		if tag.lazy == nil {
+			continue
+		}
+
+		resolved, ok := cache[tag.lazy]
+		if !ok {
+			resolved = resolveTag(tag)
+			if cache != nil {
+				cache[tag.lazy] = resolved
+			}
+		}
+
+		tags[i] = Tag{Key: tag.Key, Type: resolved.Type, Value: resolved.Value, Level: tag.Level}
+	}
+}
+
+// Returns tag with value of valuer marshaled to JSON
+// Panics in valuer are reported as "!PANIC: <panic value>" string tag value,
+// marshaling errors are reported as "!ERROR: <error>" string tag value
+func resolveTag(tag Tag) (result Tag) {
+	result = Tag{Key: tag.Key, Type: "json", Level: tag.Level}
+
+	defer func() {
+		if r := recover(); r != nil {
+			result.Type = "string"
+			result.Value = []byte(fmt.Sprintf("!PANIC: %v", r))
+		}
+	}()
+
+	value := tag.lazy.valuer.LogValue()
+	if err, ok := value.(error); ok {
+		value = err.Error()
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		result.Type = "string"
+		result.Value = []byte("!ERROR: " + err.Error())
+
+		return result
+	}
+
+	result.Value = b
+
+	return result
+}
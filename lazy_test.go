@@ -0,0 +1,163 @@
+package logw_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestLazyTags(t *testing.T) {
+	suite.Run(t, new(lazyTagsSuite))
+}
+
+type lazyTagsSuite struct {
+	suite.Suite
+}
+
+func (s *lazyTagsSuite) write(ctx context.Context, level logw.LogLevel) map[string]any {
+	b := new(bytes.Buffer)
+	log := log.New(
+		logw.LogWriter(ctx, b, logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter)),
+		"",
+		0,
+	)
+
+	log.Println(level, "test")
+
+	if b.Len() == 0 {
+		return nil
+	}
+
+	record := make(map[string]any)
+	s.Require().NoError(json.Unmarshal(b.Bytes(), &record))
+
+	return record
+}
+
+func (s *lazyTagsSuite) TestNotEvaluatedWhenFiltered() {
+	calls := 0
+	ctx := logw.AppendError(context.TODO(), "lazy", func() any {
+		calls++
+		return "value"
+	})
+
+	s.NotContains(s.write(ctx, logw.Info), "lazy")
+	s.Equal(0, calls)
+
+	s.Nil(s.write(ctx, logw.Debug))
+	s.Equal(0, calls)
+
+	s.Equal([]any{"value"}, s.write(ctx, logw.Error)["lazy"])
+	s.Equal(1, calls)
+}
+
+func (s *lazyTagsSuite) TestEvaluatedOnEveryRecord() {
+	calls := 0
+	ctx := logw.AppendInfo(context.TODO(), "lazy", logw.ValuerFunc(func() any {
+		calls++
+		return map[string]int{"calls": calls}
+	}))
+
+	s.Equal([]any{map[string]any{"calls": float64(1)}}, s.write(ctx, logw.Info)["lazy"])
+	s.Equal([]any{map[string]any{"calls": float64(2)}}, s.write(ctx, logw.Warn)["lazy"])
+	s.Equal(2, calls)
+}
+
+type userValuer struct {
+	name string
+}
+
+func (u userValuer) LogValue() any {
+	return u.name
+}
+
+func (s *lazyTagsSuite) TestValuer() {
+	ctx := logw.AppendInfo(context.TODO(), "user", userValuer{name: "john"})
+
+	s.Equal([]any{"john"}, s.write(ctx, logw.Info)["user"])
+}
+
+func (s *lazyTagsSuite) TestError() {
+	ctx := logw.AppendInfo(context.TODO(), "error", func() any { return errors.New("failed") })
+
+	s.Equal([]any{"failed"}, s.write(ctx, logw.Info)["error"])
+}
+
+func (s *lazyTagsSuite) TestPanic() {
+	ctx := logw.AppendInfo(context.TODO(), "lazy", func() any { panic("boom") })
+
+	s.Equal([]any{"!PANIC: boom"}, s.write(ctx, logw.Info)["lazy"])
+}
+
+func (s *lazyTagsSuite) TestMarshalError() {
+	ctx := logw.AppendInfo(context.TODO(), "lazy", func() any { return make(chan int) })
+
+	s.Equal([]any{"!ERROR: json: unsupported type: chan int"}, s.write(ctx, logw.Info)["lazy"])
+}
+
+func (s *lazyTagsSuite) TestNotEvaluatedWhenDropped() {
+	calls := 0
+	ctx := logw.AppendInfo(context.TODO(), "lazy", func() any {
+		calls++
+		return "value"
+	})
+
+	drop := logw.Filter(func(r logw.Record) bool { return r.Level != logw.LevelWarn })
+	b := new(bytes.Buffer)
+	log := log.New(
+		logw.LogWriter(
+			ctx,
+			b,
+			logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter),
+			logw.Sample(logw.NewSampler(1, 0, time.Hour)),
+			logw.Process(drop),
+		),
+		"",
+		0,
+	)
+
+	log.Println(logw.Info, "test")
+	log.Println(logw.Info, "test")
+	log.Println(logw.Warn, "test")
+
+	s.Equal(1, calls)
+	s.Equal(1, strings.Count(b.String(), `"lazy":["value"]`))
+}
+
+func (s *lazyTagsSuite) TestEvaluatedOncePerRecordInPipeline() {
+	dir := s.T().TempDir()
+	pipeline, err := logw.Config{
+		Sinks: []logw.SinkConfig{{Output: filepath.Join(dir, "a.log")}, {Output: filepath.Join(dir, "b.log")}},
+	}.Build()
+	s.Require().NoError(err)
+	defer pipeline.Close()
+
+	calls := 0
+	ctx := logw.AppendInfo(context.TODO(), "lazy", func() any {
+		calls++
+		return calls
+	})
+
+	log := log.New(pipeline.LogWriter(ctx), "", 0)
+	log.Println(logw.Info, "test")
+	log.Println(logw.Info, "test")
+
+	s.Equal(2, calls)
+
+	for _, name := range []string{"a.log", "b.log"} {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		s.Require().NoError(err)
+		s.Contains(string(b), `"lazy":[1]`)
+		s.Contains(string(b), `"lazy":[2]`)
+	}
+}
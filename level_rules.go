@@ -0,0 +1,225 @@
+package logw
+
+import (
+	"encoding/json"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Tag key used by component level rules
+const ComponentTagKey = "component"
+
+// Minimum level rules matched against record tags and caller package
+// Rules are matched against in-place tags and context tags of any level, lazy context tags are not matched
+// If several rules match a record the lowest level is used,
+// if no rule matches LogWriter logging level is used
+// Rules can be changed at runtime and shared by several LogWriters
+type LevelRules struct {
+	mu    sync.Mutex
+	rules atomic.Value
+}
+
+type levelRulesSnapshot struct {
+	tags    map[string]map[string]int
+	callers map[string]int
+}
+
+// Returns empty LevelRules
+func NewLevelRules() *LevelRules {
+	r := new(LevelRules)
+	r.rules.Store(&levelRulesSnapshot{})
+
+	return r
+}
+
+// Makes LogWriter filter records with rules
+// Logging level is used for records that match no rule
+func Rules(rules *LevelRules) Setting {
+	return func(w *logWriter) {
+		w.rules = rules
+	}
+}
+
+// Sets minimum level for records with "component" tag equal to component
+func (r *LevelRules) SetComponent(component string, level int) {
+	r.SetTag(ComponentTagKey, component, level)
+}
+
+// Sets minimum level for records with tag key equal to value
+func (r *LevelRules) SetTag(key, value string, level int) {
+	r.update(func(s *levelRulesSnapshot) {
+		if s.tags == nil {
+			s.tags = make(map[string]map[string]int)
+		}
+
+		if s.tags[key] == nil {
+			s.tags[key] = make(map[string]int)
+		}
+
+		s.tags[key][value] = level
+	})
+}
+
+// Sets minimum level for records written from package or its sub-packages
+// Package is matched by import path, e.g. "github.com/org/service/db"
+func (r *LevelRules) SetCaller(pkg string, level int) {
+	r.update(func(s *levelRulesSnapshot) {
+		if s.callers == nil {
+			s.callers = make(map[string]int)
+		}
+
+		s.callers[strings.TrimSuffix(pkg, "/")] = level
+	})
+}
+
+// Removes rule set with SetComponent
+func (r *LevelRules) RemoveComponent(component string) {
+	r.RemoveTag(ComponentTagKey, component)
+}
+
+// Removes rule set with SetTag
+func (r *LevelRules) RemoveTag(key, value string) {
+	r.update(func(s *levelRulesSnapshot) {
+		delete(s.tags[key], value)
+
+		if len(s.tags[key]) == 0 {
+			delete(s.tags, key)
+		}
+	})
+}
+
+// Removes rule set with SetCaller
+func (r *LevelRules) RemoveCaller(pkg string) {
+	r.update(func(s *levelRulesSnapshot) {
+		delete(s.callers, strings.TrimSuffix(pkg, "/"))
+	})
+}
+
+// Removes all rules
+func (r *LevelRules) Reset() {
+	r.update(func(s *levelRulesSnapshot) {
+		s.tags, s.callers = nil, nil
+	})
+}
+
+// Copies current rules, applies change and atomically replaces current rules with the result
+func (r *LevelRules) update(change func(*levelRulesSnapshot)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.snapshot()
+	next := &levelRulesSnapshot{}
+
+	if current.tags != nil {
+		next.tags = make(map[string]map[string]int, len(current.tags))
+		for key, values := range current.tags {
+			next.tags[key] = make(map[string]int, len(values))
+			for value, level := range values {
+				next.tags[key][value] = level
+			}
+		}
+	}
+
+	if current.callers != nil {
+		next.callers = make(map[string]int, len(current.callers))
+		for pkg, level := range current.callers {
+			next.callers[pkg] = level
+		}
+	}
+
+	change(next)
+	r.rules.Store(next)
+}
+
+func (r *LevelRules) snapshot() *levelRulesSnapshot {
+	if s, ok := r.rules.Load().(*levelRulesSnapshot); ok {
+		return s
+	}
+
+	return &levelRulesSnapshot{}
+}
+
+// Returns minimum level of rules matching tags and caller
+func (r *LevelRules) minLevel(tags []Tag) (int, bool) {
+	s := r.snapshot()
+	minLevel, matched := 0, false
+	match := func(level int) {
+		if !matched || level < minLevel {
+			minLevel, matched = level, true
+		}
+	}
+
+	if len(s.tags) > 0 {
+		for _, tag := range tags {
+			if level, ok := s.tags[tag.Key][tagText(tag)]; ok {
+				match(level)
+			}
+		}
+	}
+
+	if len(s.callers) > 0 {
+		for pkg := callerPackage(); pkg != ""; pkg = parentPackage(pkg) {
+			if level, ok := s.callers[pkg]; ok {
+				match(level)
+			}
+		}
+	}
+
+	return minLevel, matched
+}
+
+// Returns tag value as text, JSON string values are unquoted
+func tagText(tag Tag) string {
+	if tag.Type == "json" && len(tag.Value) > 0 && tag.Value[0] == '"' {
+		var s string
+		if err := json.Unmarshal(tag.Value, &s); err == nil {
+			return s
+		}
+	}
+
+	return string(tag.Value)
+}
+
+const logwPackage = "github.com/andriiyaremenko/logwriter"
+
+// Returns import path of the first caller outside of logw, its sub-packages and standard log, fmt, io and database/sql packages
+func callerPackage() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+
+	for {
+		frame, more := frames.Next()
+		pkg := functionPackage(frame.Function)
+
+		switch {
+		case pkg == logwPackage, strings.HasPrefix(pkg, logwPackage+"/") && !strings.HasSuffix(pkg, "_test"):
+		case pkg == "log", pkg == "fmt", pkg == "io", pkg == "bufio", pkg == "runtime", pkg == "database/sql":
+		default:
+			return pkg
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
+
+// Returns package import path of fully qualified function name
+func functionPackage(function string) string {
+	slash := strings.LastIndexByte(function, '/')
+	if dot := strings.IndexByte(function[slash+1:], '.'); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+
+	return function
+}
+
+func parentPackage(pkg string) string {
+	if slash := strings.LastIndexByte(pkg, '/'); slash >= 0 {
+		return pkg[:slash]
+	}
+
+	return ""
+}
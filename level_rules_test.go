@@ -0,0 +1,171 @@
+package logw_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/color"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestLevelRules(t *testing.T) {
+	suite.Run(t, new(levelRulesSuite))
+}
+
+type levelRulesSuite struct {
+	suite.Suite
+
+	b     *bytes.Buffer
+	rules *logw.LevelRules
+}
+
+func (s *levelRulesSuite) SetupTest() {
+	s.b = new(bytes.Buffer)
+	s.rules = logw.NewLevelRules()
+}
+
+func (s *levelRulesSuite) TestComponentRules() {
+	s.rules.SetComponent("db", logw.LevelDebug)
+	s.rules.SetComponent("http", logw.LevelWarn)
+
+	db := s.logger(logw.AppendInfo(context.TODO(), logw.ComponentTagKey, "db"))
+	http := s.logger(logw.AppendInfo(context.TODO(), logw.ComponentTagKey, "http"))
+	other := s.logger(context.TODO())
+
+	db.Println(logw.Debug, "db debug")
+	http.Println(logw.Info, "http info")
+	http.Println(logw.Warn, "http warn")
+	other.Println(logw.Debug, "other debug")
+	other.Println(logw.Info, "other info")
+
+	s.Equal([]string{"db debug", "http warn", "other info"}, s.messages())
+}
+
+func (s *levelRulesSuite) TestTagRules() {
+	s.rules.SetTag("user", "test", logw.LevelTrace)
+	s.rules.SetTag("user", "noisy", logw.LevelError)
+
+	log := s.logger(context.TODO())
+
+	log.Println(logw.Trace.WithString("user", "test"), "traced")
+	log.Println(logw.Warn.WithString("user", "noisy"), "dropped")
+	log.Println(logw.Error.WithString("user", "noisy"), "kept")
+
+	s.Equal([]string{"traced", "kept"}, s.messages())
+}
+
+func (s *levelRulesSuite) TestLowestMatchingLevelWins() {
+	s.rules.SetComponent("db", logw.LevelError)
+	s.rules.SetTag("request_id", "debug-me", logw.LevelDebug)
+
+	ctx := logw.AppendInfo(context.TODO(), logw.ComponentTagKey, "db")
+	log := s.logger(ctx)
+
+	log.Println(logw.Debug.WithString("request_id", "debug-me"), "debugged")
+	log.Println(logw.Warn.WithString("request_id", "other"), "dropped")
+
+	s.Equal([]string{"debugged"}, s.messages())
+}
+
+func (s *levelRulesSuite) TestCallerRules() {
+	s.rules.SetCaller("github.com/andriiyaremenko/logwriter_test", logw.LevelDebug)
+
+	log := s.logger(context.TODO())
+	log.Println(logw.Debug, "test package")
+
+	s.rules.SetCaller("github.com/andriiyaremenko/logwriter_test/", logw.LevelError)
+	log.Println(logw.Warn, "dropped")
+
+	s.rules.RemoveCaller("github.com/andriiyaremenko/logwriter_test")
+	s.rules.SetCaller("github.com/andriiyaremenko", logw.LevelTrace)
+	log.Println(logw.Trace, "parent package")
+
+	s.rules.SetCaller("github.com/andriiyaremenko/other", logw.LevelFatal)
+	log.Println(logw.Info, "other package")
+
+	s.Equal([]string{"test package", "parent package", "other package"}, s.messages())
+}
+
+func (s *levelRulesSuite) TestRuntimeChanges() {
+	ctx := logw.AppendInfo(context.TODO(), logw.ComponentTagKey, "db")
+	log := s.logger(ctx)
+
+	log.Println(logw.Debug, "first")
+
+	s.rules.SetComponent("db", logw.LevelDebug)
+	log.Println(logw.Debug, "second")
+
+	s.rules.RemoveComponent("db")
+	log.Println(logw.Debug, "third")
+
+	s.rules.SetComponent("db", logw.LevelDebug)
+	s.rules.Reset()
+	log.Println(logw.Debug, "fourth")
+
+	s.Equal([]string{"second"}, s.messages())
+}
+
+func (s *levelRulesSuite) TestConcurrentUpdates() {
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				s.rules.SetComponent("db", j%5)
+				s.rules.RemoveComponent("db")
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			log := log.New(
+				logw.LogWriter(
+					logw.AppendInfo(context.TODO(), logw.ComponentTagKey, "db"),
+					new(bytes.Buffer),
+					logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter),
+					logw.Rules(s.rules),
+				),
+				"",
+				0,
+			)
+
+			for j := 0; j < 100; j++ {
+				log.Println(logw.Debug, "test")
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (s *levelRulesSuite) logger(ctx context.Context) *log.Logger {
+	return log.New(
+		logw.LogWriter(ctx, s.b, logw.Option(logw.LevelInfo, logw.NewTextFormatter(s.b, color.Never), logw.NoDate), logw.Rules(s.rules)),
+		"",
+		0,
+	)
+}
+
+func (s *levelRulesSuite) messages() []string {
+	messages := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(s.b.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, "  ")
+		messages = append(messages, strings.TrimSpace(fields[len(fields)-1]))
+	}
+
+	return messages
+}
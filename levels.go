@@ -0,0 +1,168 @@
+package logw
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/andriiyaremenko/logwriter/color"
+)
+
+var (
+	customLevelsMu    sync.RWMutex
+	customLevelNames  = make(map[int]string)
+	customLevelByName = make(map[string]int)
+	// length of the longest builtin or registered level name
+	maxLevelNameWidth = len("error")
+
+	builtinLevels = map[string]int{
+		"trace": LevelTrace,
+		"debug": LevelDebug,
+		"info":  LevelInfo,
+		"warn":  LevelWarn,
+		"error": LevelError,
+		"fatal": LevelFatal,
+	}
+)
+
+// Registers custom named level (e.g. "finest" below Trace or "critical" above Fatal)
+// Returns LogLevel that can be used the same way as Debug|Info|Warn|Error|Fatal
+// Custom level codes are compared with logging level as any other level code
+// Codes from LevelTrace to LevelFatal are reserved, so custom levels rank below Trace or above Fatal,
+// custom levels above Fatal do not trigger FatalHandler
+func RegisterLevel(code int, name string, c color.Color) (LogLevel, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "", fmt.Errorf("level name cannot be empty")
+	}
+
+	if _, ok := builtinLevels[name]; ok {
+		return "", fmt.Errorf("level name %q is reserved", name)
+	}
+
+	if code >= LevelTrace && code <= LevelFatal {
+		return "", fmt.Errorf("level code %d is reserved for %q level", code, FormatLogLevel(code))
+	}
+
+	customLevelsMu.Lock()
+	defer customLevelsMu.Unlock()
+
+	if registered, ok := customLevelByName[name]; ok && registered != code {
+		return "", fmt.Errorf("level name %q is already registered with code %d", name, registered)
+	}
+
+	if registered, ok := customLevelNames[code]; ok && registered != name {
+		return "", fmt.Errorf("level code %d is already registered as %q", code, registered)
+	}
+
+	customLevelNames[code] = name
+	customLevelByName[name] = code
+	color.SetLevelColor(code, c)
+
+	if width := utf8.RuneCountInString(name); width > maxLevelNameWidth {
+		maxLevelNameWidth = width
+	}
+
+	return Level(code), nil
+}
+
+// Returns level code for level name or level code string
+// Recognizes custom levels registered with RegisterLevel
+func ParseLevel(name string) (int, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if code, ok := builtinLevels[name]; ok {
+		return code, nil
+	}
+
+	customLevelsMu.RLock()
+	code, ok := customLevelByName[name]
+	customLevelsMu.RUnlock()
+
+	if ok {
+		return code, nil
+	}
+
+	code, err := strconv.Atoi(name)
+	if err != nil {
+		return 0, fmt.Errorf("unknown level %q", name)
+	}
+
+	return code, nil
+}
+
+func customLevelName(level int) (string, bool) {
+	customLevelsMu.RLock()
+	defer customLevelsMu.RUnlock()
+
+	name, ok := customLevelNames[level]
+	return name, ok
+}
+
+// Returns length of the longest builtin or registered level name
+func levelNameWidth() int {
+	customLevelsMu.RLock()
+	defer customLevelsMu.RUnlock()
+
+	return maxLevelNameWidth
+}
+
+// Tag key for original level of ingested records with fatal level
+const OriginalLevelTagKey = "original_level"
+
+// Returns level of record ingested from subprocess output or other logging library
+// Fatal levels are capped at Error so external input never makes FatalHandler terminate the program,
+// original level name is returned if level was capped
+func ingestedLevel(level int) (int, string) {
+	if !isFatal(level) {
+		return level, ""
+	}
+
+	return LevelError, FormatLogLevel(level)
+}
+
+// Reports if record with level is handled by FatalHandler
+// Panic levels of other loggers are mapped to Error, so only Fatal records terminate the program
+// and logger that raised panic can still recover
+func isFatal(level int) bool {
+	if level < LevelFatal {
+		return false
+	}
+
+	_, custom := customLevelName(level)
+	return !custom
+}
+
+// Returns level code for level names commonly used by other loggers and tools
+// Custom levels registered with RegisterLevel take precedence
+// "panic" and "dpanic" are mapped to Error level, see isFatal
+func levelFromAlias(name string) (int, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	customLevelsMu.RLock()
+	code, ok := customLevelByName[name]
+	customLevelsMu.RUnlock()
+
+	if ok {
+		return code, true
+	}
+
+	switch name {
+	case "trc", "trace":
+		return LevelTrace, true
+	case "dbg", "debug":
+		return LevelDebug, true
+	case "inf", "info", "information", "notice":
+		return LevelInfo, true
+	case "wrn", "warn", "warning":
+		return LevelWarn, true
+	case "err", "error", "panic", "dpanic":
+		return LevelError, true
+	case "ftl", "fatal", "crit", "critical", "emerg", "alert":
+		return LevelFatal, true
+	}
+
+	return 0, false
+}
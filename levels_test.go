@@ -0,0 +1,179 @@
+package logw_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/color"
+	"github.com/andriiyaremenko/logwriter/logwtest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestLevels(t *testing.T) {
+	suite.Run(t, new(levelsSuite))
+}
+
+type levelsSuite struct {
+	suite.Suite
+}
+
+func (s *levelsSuite) TestTraceLevel() {
+	b := new(bytes.Buffer)
+	log := log.New(logw.LogWriter(context.TODO(), b, logw.Option(logw.LevelTrace, logw.JSONFormatter, logw.NoDate)), "", 0)
+
+	log.Println(logw.Trace, "test")
+
+	s.Equal(0, logw.LevelTrace)
+	s.Equal("{\"levelCode\":0,\"level\":\"trace\",\"message\":\"test\"}\n", b.String())
+}
+
+func (s *levelsSuite) TestTraceLevelIsFilteredByDefault() {
+	b := new(bytes.Buffer)
+	log := log.New(logw.JSONLogWriter(context.TODO(), b), "", 0)
+
+	log.Println(logw.Trace, "test")
+
+	s.Empty(b.String())
+}
+
+func (s *levelsSuite) TestTraceContextTags() {
+	b := new(bytes.Buffer)
+	ctx := logw.AppendTrace(context.TODO(), "foo", "bar")
+	log := log.New(logw.LogWriter(ctx, b, logw.NoTimeStampOption(logw.LevelTrace, logw.JSONFormatter)), "", 0)
+
+	log.Println(logw.Trace, "test")
+	log.Println(logw.Info, "test")
+
+	s.Equal(
+		"{\"levelCode\":0,\"level\":\"trace\",\"message\":\"test\",\"foo\":[\"bar\"]}\n"+
+			"{\"levelCode\":2,\"level\":\"info\",\"message\":\"test\",\"foo\":[\"bar\"]}\n",
+		b.String(),
+	)
+}
+
+func (s *levelsSuite) TestCustomLevel() {
+	emergency, err := logw.RegisterLevel(30, "Emergency", color.ANSIColorBlue)
+	s.Require().NoError(err)
+
+	b := new(bytes.Buffer)
+	log := log.New(logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter)), "", 0)
+	log.Println(emergency, "test")
+
+	s.Equal("emergency", logw.FormatLogLevel(30))
+	s.Equal(color.ANSIColorBlue, color.GetLevelColor(30))
+	s.Equal("{\"levelCode\":30,\"level\":\"emergency\",\"message\":\"test\"}\n", b.String())
+
+	b.Reset()
+	log.SetOutput(logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelInfo, logw.TextFormatter)))
+	log.Println(emergency, "test")
+
+	s.Equal(color.ColorizeText(color.ANSIColorBlue, "emergency")+"  test\n", b.String())
+}
+
+func (s *levelsSuite) TestCustomLevelFiltering() {
+	finest, err := logw.RegisterLevel(-10, "finest", color.ANSIColorPurple)
+	s.Require().NoError(err)
+
+	b := new(bytes.Buffer)
+	log := log.New(logw.JSONLogWriter(context.TODO(), b), "", 0)
+	log.Println(finest, "test")
+
+	s.Empty(b.String())
+
+	log.SetOutput(logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(-10, logw.JSONFormatter)))
+	log.Println(finest, "test")
+	log.Println(logw.Trace, "test")
+
+	s.Equal(
+		"{\"levelCode\":-10,\"level\":\"finest\",\"message\":\"test\"}\n"+
+			"{\"levelCode\":0,\"level\":\"trace\",\"message\":\"test\"}\n",
+		b.String(),
+	)
+
+	code, err := logw.ParseLevel("FINEST")
+	s.NoError(err)
+	s.Equal(-10, code)
+}
+
+func (s *levelsSuite) TestCustomLevelAboveFatalDoesNotExit() {
+	critical, err := logw.RegisterLevel(40, "critical", color.ANSIFontBold+color.ANSIColorPurple)
+	s.Require().NoError(err)
+
+	recorder := new(logwtest.ExitRecorder)
+	h := logw.NewFatalHandler(1, time.Second)
+	h.Exit = recorder.Exit
+
+	b := new(bytes.Buffer)
+	log := log.New(logw.JSONLogWriter(context.TODO(), b, logw.OnFatal(h)), "", 0)
+	log.Println(critical, "test")
+
+	s.Contains(b.String(), "\"level\":\"critical\"")
+	s.False(recorder.Exited())
+}
+
+func (s *levelsSuite) TestConsoleAlignsCustomLevels() {
+	critical, err := logw.RegisterLevel(40, "critical", color.ANSIFontBold+color.ANSIColorPurple)
+	s.Require().NoError(err)
+
+	b := new(bytes.Buffer)
+	log := log.New(
+		logw.LogWriter(context.TODO(), b, logw.Option(logw.LevelDebug, logw.NewConsoleFormatter(b), logw.NoDate)),
+		"",
+		0,
+	)
+
+	log.Println(logw.Info, "first")
+	log.Println(critical, "second")
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	s.Require().Len(lines, 2)
+
+	s.True(strings.HasPrefix(lines[0], "info "))
+	s.True(strings.HasPrefix(lines[1], "critical  "))
+	s.Equal(strings.Index(lines[0], "first"), strings.Index(lines[1], "second"))
+}
+
+func (s *levelsSuite) TestRegisterLevelErrors() {
+	_, err := logw.RegisterLevel(50, "", color.ANSIColorBlue)
+	s.Error(err)
+
+	_, err = logw.RegisterLevel(50, "warn", color.ANSIColorBlue)
+	s.Error(err)
+
+	_, err = logw.RegisterLevel(logw.LevelWarn, "warning", color.ANSIColorBlue)
+	s.Error(err)
+
+	_, err = logw.RegisterLevel(51, "unique", color.ANSIColorBlue)
+	s.NoError(err)
+
+	_, err = logw.RegisterLevel(52, "unique", color.ANSIColorBlue)
+	s.Error(err)
+
+	_, err = logw.RegisterLevel(51, "other", color.ANSIColorBlue)
+	s.Error(err)
+}
+
+func (s *levelsSuite) TestParseLevel() {
+	for name, code := range map[string]int{
+		"trace": logw.LevelTrace,
+		"Debug": logw.LevelDebug,
+		"INFO":  logw.LevelInfo,
+		"warn":  logw.LevelWarn,
+		"error": logw.LevelError,
+		"fatal": logw.LevelFatal,
+		"3":     logw.LevelWarn,
+	} {
+		level, err := logw.ParseLevel(name)
+
+		s.NoError(err)
+		s.Equal(code, level)
+	}
+
+	_, err := logw.ParseLevel("unknown")
+	s.Error(err)
+}
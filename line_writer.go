@@ -0,0 +1,179 @@
+package logw
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// Default LineWriter per-record buffer size
+const DefaultMaxRecordSize = 64 * 1024
+
+// Returned by LineWriter.Write after LineWriter was closed
+var ErrWriterClosed = errors.New("logw: writer is closed")
+
+// Line-assembling writer for producers that do not write one record per Write call
+// (fmt.Fprint loops, bufio.Writer flushes, exec.Cmd output)
+// Buffers partial writes and writes every complete line to w as a separate record
+// In-place level headers spanning several lines are kept within one record
+// Records longer than maxSize are written in maxSize chunks
+// Leftovers are written on Close or when no writes happened for timeout (if timeout > 0)
+func LineWriter(w io.Writer, maxSize int, timeout time.Duration) io.WriteCloser {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxRecordSize
+	}
+
+	return &lineWriter{w: w, maxSize: maxSize, timeout: timeout}
+}
+
+type lineWriter struct {
+	w       io.Writer
+	maxSize int
+	timeout time.Duration
+
+	mu     sync.Mutex
+	buf    []byte
+	timer  *time.Timer
+	closed bool
+}
+
+func (lw *lineWriter) Write(p []byte) (int, error) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	if lw.closed {
+		return 0, ErrWriterClosed
+	}
+
+	var err error
+	for n := 0; n < len(p); {
+		// buffer never holds more than maxSize bytes
+		chunk := p[n:]
+		if free := lw.maxSize - len(lw.buf); len(chunk) > free {
+			chunk = chunk[:free]
+		}
+
+		lw.buf = append(lw.buf, chunk...)
+		n += len(chunk)
+
+		for {
+			end := recordEnd(lw.buf)
+			if end == -1 && len(lw.buf) >= lw.maxSize {
+				end = lw.maxSize
+			}
+
+			if end == -1 {
+				break
+			}
+
+			if _, wErr := lw.w.Write(lw.buf[:end]); wErr != nil && err == nil {
+				err = wErr
+			}
+
+			lw.buf = lw.buf[end:]
+		}
+	}
+
+	if len(lw.buf) == 0 {
+		lw.buf = nil
+	}
+
+	lw.resetTimer()
+
+	return len(p), err
+}
+
+// Writes leftovers and stops LineWriter
+func (lw *lineWriter) Close() error {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	if lw.closed {
+		return nil
+	}
+
+	lw.closed = true
+	if lw.timer != nil {
+		lw.timer.Stop()
+	}
+
+	return lw.flush()
+}
+
+func (lw *lineWriter) resetTimer() {
+	if lw.timeout <= 0 || len(lw.buf) == 0 {
+		return
+	}
+
+	if lw.timer == nil {
+		lw.timer = time.AfterFunc(lw.timeout, func() {
+			lw.mu.Lock()
+			defer lw.mu.Unlock()
+
+			if !lw.closed {
+				_ = lw.flush()
+			}
+		})
+
+		return
+	}
+
+	lw.timer.Reset(lw.timeout)
+}
+
+func (lw *lineWriter) flush() error {
+	if len(lw.buf) == 0 {
+		return nil
+	}
+
+	_, err := lw.w.Write(lw.buf)
+	lw.buf = nil
+
+	return err
+}
+
+// Returns length of the first complete record in b or -1 if record is not complete yet
+// Line with level header starts multi-line record only if every following line up to closing header is a tag row
+func recordEnd(b []byte) int {
+	newLine := bytes.IndexByte(b, '\n')
+	if newLine == -1 {
+		return -1
+	}
+
+	line := b[:newLine+1]
+	header := []byte(logwHeader)
+	start := bytes.Index(line, header)
+
+	if v1 := bytes.Index(line, []byte(logwHeaderV1)); v1 != -1 && (start == -1 || v1 < start) {
+		header, start = []byte(logwHeaderV1), v1
+	}
+
+	if start == -1 {
+		return newLine + 1
+	}
+
+	headersEnd := start + len(header)
+	for !bytes.HasPrefix(b[headersEnd:], header) {
+		rowEnd := bytes.IndexByte(b[headersEnd:], '\n')
+		if rowEnd == -1 {
+			return -1
+		}
+
+		// tag rows have key, value and type fields separated with tabs
+		if bytes.Count(b[headersEnd:headersEnd+rowEnd], []byte("\t")) < 2 {
+			return newLine + 1
+		}
+
+		headersEnd += rowEnd + 1
+	}
+
+	headersEnd += len(header)
+	newLine = bytes.IndexByte(b[headersEnd:], '\n')
+	if newLine == -1 {
+		return -1
+	}
+
+	return headersEnd + newLine + 1
+}
@@ -0,0 +1,148 @@
+package logw_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestLineWriter(t *testing.T) {
+	suite.Run(t, new(lineWriterSuite))
+}
+
+type lineWriterSuite struct {
+	suite.Suite
+}
+
+type recordsWriter struct {
+	mu      sync.Mutex
+	records []string
+}
+
+func (w *recordsWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.records = append(w.records, string(p))
+	return len(p), nil
+}
+
+func (w *recordsWriter) Records() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]string(nil), w.records...)
+}
+
+func (s *lineWriterSuite) TestPartialWrites() {
+	records := new(recordsWriter)
+	w := logw.LineWriter(records, 0, 0)
+
+	fmt.Fprint(w, "first ")
+	fmt.Fprint(w, "record")
+	s.Empty(records.Records())
+
+	fmt.Fprint(w, "\nsecond record\nthird")
+	s.Equal([]string{"first record\n", "second record\n"}, records.Records())
+
+	s.NoError(w.Close())
+	s.Equal([]string{"first record\n", "second record\n", "third"}, records.Records())
+}
+
+func (s *lineWriterSuite) TestBufferedWrites() {
+	records := new(recordsWriter)
+	w := logw.LineWriter(records, 0, 0)
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "first")
+	fmt.Fprintln(bw, logw.Warn, "second")
+	fmt.Fprintln(bw, "third")
+	s.NoError(bw.Flush())
+
+	s.Equal(
+		[]string{"first\n", fmt.Sprintln(logw.Warn, "second"), "third\n"},
+		records.Records(),
+	)
+}
+
+func (s *lineWriterSuite) TestLevelHeaderSplitAcrossWrites() {
+	b := new(bytes.Buffer)
+	option := logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter)
+	w := logw.LineWriter(logw.LogWriter(context.TODO(), b, option), 0, 0)
+
+	record := logw.Error.WithString("foo", "bar").WithMessage("test") + "\n"
+	for _, c := range []byte(record) {
+		_, err := w.Write([]byte{c})
+		s.NoError(err)
+	}
+
+	s.Equal("{\"levelCode\":4,\"level\":\"error\",\"message\":\"test\",\"foo\":[\"bar\"]}\n", b.String())
+}
+
+func (s *lineWriterSuite) TestHeaderWithoutTagRows() {
+	records := new(recordsWriter)
+	w := logw.LineWriter(records, 0, 0)
+
+	fmt.Fprint(w, "user wrote -logw-\nthen more\nand more\n")
+	s.Equal([]string{"user wrote -logw-\n", "then more\n", "and more\n"}, records.Records())
+
+	fmt.Fprint(w, "user wrote -logw-v2-\n")
+	s.Len(records.Records(), 3)
+
+	fmt.Fprint(w, "then more\n")
+	s.Equal(
+		[]string{"user wrote -logw-\n", "then more\n", "and more\n", "user wrote -logw-v2-\n", "then more\n"},
+		records.Records(),
+	)
+}
+
+func (s *lineWriterSuite) TestMaxSize() {
+	records := new(recordsWriter)
+	w := logw.LineWriter(records, 8, 0)
+
+	fmt.Fprint(w, strings.Repeat("a", 20))
+	fmt.Fprint(w, "\n")
+
+	s.Equal([]string{"aaaaaaaa", "aaaaaaaa", "aaaa\n"}, records.Records())
+}
+
+func (s *lineWriterSuite) TestMaxSizeOfCompleteLines() {
+	records := new(recordsWriter)
+	w := logw.LineWriter(records, 8, 0)
+
+	fmt.Fprint(w, strings.Repeat("a", 20)+"\nbb\n"+strings.Repeat("c", 10)+"\n")
+
+	s.Equal([]string{"aaaaaaaa", "aaaaaaaa", "aaaa\n", "bb\n", "cccccccc", "cc\n"}, records.Records())
+}
+
+func (s *lineWriterSuite) TestTimeout() {
+	records := new(recordsWriter)
+	w := logw.LineWriter(records, 0, 10*time.Millisecond)
+	defer w.Close()
+
+	fmt.Fprint(w, "leftover")
+
+	s.Eventually(
+		func() bool { return len(records.Records()) == 1 },
+		time.Second,
+		time.Millisecond,
+	)
+	s.Equal([]string{"leftover"}, records.Records())
+}
+
+func (s *lineWriterSuite) TestWriteAfterClose() {
+	w := logw.LineWriter(new(recordsWriter), 0, 0)
+
+	s.NoError(w.Close())
+
+	_, err := fmt.Fprint(w, "test")
+	s.ErrorIs(err, logw.ErrWriterClosed)
+}
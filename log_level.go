@@ -1,14 +1,17 @@
 package logw
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"runtime"
 	"strconv"
 	"strings"
 )
 
 const (
-	_ int = iota
+	// Trace level code
+	LevelTrace int = iota
 	// Debug level code
 	LevelDebug
 	// Info level code
@@ -21,9 +24,18 @@ const (
 	LevelFatal
 )
 
-const logwHeader string = "-logw-\n"
+const (
+	// Current wire format header
+	// Tag fields are percent-escaped, so they can hold arbitrary bytes
+	logwHeader string = "-logw-v2-\n"
+	// Legacy wire format header
+	// Tag fields are written as is
+	logwHeaderV1 string = "-logw-\n"
+)
 
 var (
+	// Sets Trace message level
+	Trace LogLevel = Level(LevelTrace)
 	// Sets Debug message level
 	Debug LogLevel = Level(LevelDebug)
 	// Sets Info message level
@@ -54,8 +66,6 @@ type LogLevel string
 
 // Adds in-place tag with string value
 func (t LogLevel) WithString(tag string, value string) LogLevel {
-	value = strings.ReplaceAll(value, "\t", " ")
-	value = strings.ReplaceAll(value, "\n", " ")
 	return t.appendTag(tag, value, "string")
 }
 
@@ -74,6 +84,55 @@ func (t LogLevel) WithBool(tag string, value bool) LogLevel {
 	return t.appendTag(tag, strconv.FormatBool(value), "bool")
 }
 
+// Adds in-place tag with value of any type
+// Strings, integers, floats, bools and errors are added as typed tags,
+// other values are marshaled to JSON
+func (t LogLevel) WithAny(tag string, value any) LogLevel {
+	switch v := value.(type) {
+	case string:
+		return t.WithString(tag, v)
+	case error:
+		return t.WithString(tag, v.Error())
+	case bool:
+		return t.WithBool(tag, v)
+	case int:
+		return t.WithInt(tag, v)
+	case int8:
+		return t.WithInt(tag, int(v))
+	case int16:
+		return t.WithInt(tag, int(v))
+	case int32:
+		return t.WithInt(tag, int(v))
+	case int64:
+		return t.appendTag(tag, strconv.FormatInt(v, 10), "int")
+	case uint8:
+		return t.WithInt(tag, int(v))
+	case uint16:
+		return t.WithInt(tag, int(v))
+	case uint32:
+		return t.appendTag(tag, strconv.FormatUint(uint64(v), 10), "int")
+	case uint:
+		return t.appendTag(tag, strconv.FormatUint(uint64(v), 10), "int")
+	case uint64:
+		return t.appendTag(tag, strconv.FormatUint(v, 10), "int")
+	case float32:
+		return t.appendTag(tag, strconv.FormatFloat(float64(v), 'f', -1, 32), "float64")
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return t.WithString(tag, strconv.FormatFloat(v, 'f', -1, 64))
+		}
+
+		return t.WithFloat(tag, v)
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return t.WithString(tag, fmt.Sprint(value))
+	}
+
+	return t.appendTag(tag, string(b), "json")
+}
+
 // Adds in-place trace tag with file name and row number
 // Tag key: "trace"
 func (t LogLevel) WithTrace() LogLevel {
@@ -94,7 +153,12 @@ func (t LogLevel) Error(err error) LogLevel {
 func (t LogLevel) appendTag(tag, value, valueType string) LogLevel {
 	return LogLevel(
 		strings.Join(
-			[]string{logwHeader, tag, "\t", value, "\t", valueType, "\n", string(t[logwHeaderLen:])}, "",
+			[]string{
+				logwHeader,
+				escapeField(tag), "\t", escapeField(value), "\t", escapeField(valueType), "\n",
+				string(t[logwHeaderLen:]),
+			},
+			"",
 		),
 	)
 }
@@ -0,0 +1,9 @@
+// Package logwgrpc provides gRPC server and client interceptors that add request-scoped logw tags
+// to the context and log call completion with gRPC status code mapped to logw level.
+//
+// How to use:
+// 	server := grpc.NewServer(
+// 		grpc.UnaryInterceptor(logwgrpc.UnaryServerInterceptor(os.Stdout, logw.JSONOption)),
+// 		grpc.StreamInterceptor(logwgrpc.StreamServerInterceptor(os.Stdout, logw.JSONOption)),
+// 	)
+package logwgrpc
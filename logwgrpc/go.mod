@@ -0,0 +1,24 @@
+module github.com/andriiyaremenko/logwriter/logwgrpc
+
+go 1.18
+
+require (
+	github.com/andriiyaremenko/logwriter v0.0.0-20261019161318-49fed3452606
+	github.com/stretchr/testify v1.8.0
+	google.golang.org/grpc v1.50.1
+	google.golang.org/protobuf v1.27.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.0.0-20201021035429-f5854403a974 // indirect
+	golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
+	golang.org/x/text v0.3.3 // indirect
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// root module of the same checkout is used in development and tests
+replace github.com/andriiyaremenko/logwriter => ../
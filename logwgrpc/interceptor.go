@@ -0,0 +1,402 @@
+package logwgrpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Metadata key used to read and propagate request id
+const RequestIDMetadataKey = "x-request-id"
+
+// Interceptor option
+type Option func(*options)
+
+type options struct {
+	settings       []logw.Setting
+	logPayloads    bool
+	maxPayloadSize int
+}
+
+// Logs request and response payloads with Debug level
+// Payloads are marshaled only if interceptor logging level is Debug or lower
+// Payloads longer than maxSize bytes are truncated on UTF-8 character boundary
+func LogPayloads(maxSize int) Option {
+	return func(o *options) {
+		o.logPayloads = true
+		o.maxPayloadSize = maxSize
+	}
+}
+
+// Applies logw settings to LogWriter used by interceptor
+func WithSettings(settings ...logw.Setting) Option {
+	return func(o *options) {
+		o.settings = append(o.settings, settings...)
+	}
+}
+
+// Returns server interceptor that adds "method", "peer" and "request_id" tags to the context
+// and logs call completion with "code" and "duration_ms" tags
+// Request id is taken from x-request-id metadata or generated
+func UnaryServerInterceptor(w io.Writer, conf logw.LogWriterOption, opts ...Option) grpc.UnaryServerInterceptor {
+	i := newInterceptor(w, conf, opts)
+
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		start := time.Now()
+		ctx = i.serverContext(ctx, info.FullMethod)
+		logger := i.logger(ctx)
+
+		i.logPayload(logger, "request payload", req)
+
+		resp, err := handler(ctx, req)
+		if err == nil {
+			i.logPayload(logger, "response payload", resp)
+		}
+
+		i.logCompletion(logger, err, start)
+
+		return resp, err
+	}
+}
+
+// Returns stream server interceptor that adds "method", "peer" and "request_id" tags to the stream context
+// and logs stream completion with "code" and "duration_ms" tags
+// Request id is taken from x-request-id metadata or generated
+func StreamServerInterceptor(w io.Writer, conf logw.LogWriterOption, opts ...Option) grpc.StreamServerInterceptor {
+	i := newInterceptor(w, conf, opts)
+
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+		ctx := i.serverContext(ss.Context(), info.FullMethod)
+		logger := i.logger(ctx)
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx, interceptor: i, logger: logger})
+		i.logCompletion(logger, err, start)
+
+		return err
+	}
+}
+
+// Returns client interceptor that adds "method", "peer" and "request_id" tags to the context,
+// propagates request id in x-request-id metadata and logs call completion with "code" and "duration_ms" tags
+func UnaryClientInterceptor(w io.Writer, conf logw.LogWriterOption, opts ...Option) grpc.UnaryClientInterceptor {
+	i := newInterceptor(w, conf, opts)
+
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		callOpts ...grpc.CallOption,
+	) error {
+		start := time.Now()
+		ctx = i.clientContext(ctx, method, cc.Target())
+		logger := i.logger(ctx)
+
+		i.logPayload(logger, "request payload", req)
+
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+		if err == nil {
+			i.logPayload(logger, "response payload", reply)
+		}
+
+		i.logCompletion(logger, err, start)
+
+		return err
+	}
+}
+
+// Returns stream client interceptor that adds "method", "peer" and "request_id" tags to the context,
+// propagates request id in x-request-id metadata and logs stream completion with "code" and "duration_ms" tags
+// Completion is logged when stream ends, when response of stream without server streaming is received
+// or when stream context is done
+func StreamClientInterceptor(w io.Writer, conf logw.LogWriterOption, opts ...Option) grpc.StreamClientInterceptor {
+	i := newInterceptor(w, conf, opts)
+
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		callOpts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		start := time.Now()
+		ctx = i.clientContext(ctx, method, cc.Target())
+		logger := i.logger(ctx)
+
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			i.logCompletion(logger, err, start)
+			return nil, err
+		}
+
+		stream := &clientStream{
+			ClientStream:  cs,
+			interceptor:   i,
+			logger:        logger,
+			start:         start,
+			serverStreams: desc.ServerStreams,
+			done:          make(chan struct{}),
+		}
+
+		if ctx.Done() != nil {
+			go stream.watch(ctx)
+		}
+
+		return stream, nil
+	}
+}
+
+type interceptor struct {
+	options
+
+	out  io.Writer
+	conf logw.LogWriterOption
+}
+
+func newInterceptor(w io.Writer, conf logw.LogWriterOption, opts []Option) *interceptor {
+	i := &interceptor{out: logw.SyncWriter(w), conf: conf}
+	for _, opt := range opts {
+		opt(&i.options)
+	}
+
+	return i
+}
+
+func (i *interceptor) logger(ctx context.Context) *log.Logger {
+	return log.New(logw.LogWriter(ctx, i.out, i.conf, i.settings...), "", 0)
+}
+
+func (i *interceptor) serverContext(ctx context.Context, method string) context.Context {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDMetadataKey); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+
+	if requestID == "" {
+		requestID = logw.NewRequestID()
+	}
+
+	peerAddr := ""
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		peerAddr = p.Addr.String()
+	}
+
+	ctx = logw.AppendInfo(ctx, "method", method)
+	ctx = logw.AppendInfo(ctx, "peer", peerAddr)
+
+	return logw.AppendInfo(ctx, "request_id", requestID)
+}
+
+func (i *interceptor) clientContext(ctx context.Context, method, target string) context.Context {
+	requestID := ""
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		if values := md.Get(RequestIDMetadataKey); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+
+	if requestID == "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(RequestIDMetadataKey); len(values) > 0 {
+				requestID = values[0]
+			}
+		}
+
+		if requestID == "" {
+			requestID = logw.NewRequestID()
+		}
+
+		ctx = metadata.AppendToOutgoingContext(ctx, RequestIDMetadataKey, requestID)
+	}
+
+	ctx = logw.AppendInfo(ctx, "method", method)
+	ctx = logw.AppendInfo(ctx, "peer", target)
+
+	return logw.AppendInfo(ctx, "request_id", requestID)
+}
+
+func (i *interceptor) logCompletion(logger *log.Logger, err error, start time.Time) {
+	code := status.Code(err)
+	level := codeLevel(code).
+		WithString("code", code.String()).
+		WithFloat("duration_ms", float64(time.Since(start))/float64(time.Millisecond))
+
+	if err != nil {
+		level = level.Error(err)
+	}
+
+	logger.Println(level, "call completed")
+}
+
+func (i *interceptor) logPayload(logger *log.Logger, message string, payload any) {
+	if !i.logPayloads {
+		return
+	}
+
+	if level, _, _ := i.conf(); level > logw.LevelDebug {
+		return
+	}
+
+	var b []byte
+	if m, ok := payload.(proto.Message); ok {
+		b, _ = protojson.Marshal(m)
+	} else {
+		b = []byte(fmt.Sprint(payload))
+	}
+
+	size := len(b)
+	truncated := i.maxPayloadSize > 0 && size > i.maxPayloadSize
+	if truncated {
+		end := i.maxPayloadSize
+		for end > 0 && !utf8.RuneStart(b[end]) {
+			end--
+		}
+
+		b = b[:end]
+	}
+
+	logger.Println(
+		logw.Debug.
+			WithString("payload", string(b)).
+			WithInt("payload_size", size).
+			WithBool("payload_truncated", truncated),
+		message,
+	)
+}
+
+// Maps gRPC status code to logw level
+// Client errors are logged with Warn level, server errors with Error level
+func codeLevel(code codes.Code) logw.LogLevel {
+	switch code {
+	case codes.OK:
+		return logw.Info
+	case codes.Canceled,
+		codes.InvalidArgument,
+		codes.NotFound,
+		codes.AlreadyExists,
+		codes.PermissionDenied,
+		codes.Unauthenticated,
+		codes.FailedPrecondition,
+		codes.OutOfRange,
+		codes.ResourceExhausted,
+		codes.Aborted:
+		return logw.Warn
+	}
+
+	return logw.Error
+}
+
+type serverStream struct {
+	grpc.ServerStream
+
+	ctx         context.Context
+	interceptor *interceptor
+	logger      *log.Logger
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.interceptor.logPayload(s.logger, "received payload", m)
+	}
+
+	return err
+}
+
+func (s *serverStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.interceptor.logPayload(s.logger, "sent payload", m)
+	}
+
+	return err
+}
+
+type clientStream struct {
+	grpc.ClientStream
+
+	interceptor   *interceptor
+	logger        *log.Logger
+	start         time.Time
+	serverStreams bool
+	once          sync.Once
+	done          chan struct{}
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		s.interceptor.logPayload(s.logger, "received payload", m)
+
+		if !s.serverStreams {
+			s.complete(nil)
+		}
+
+		return nil
+	}
+
+	if err == io.EOF {
+		s.complete(nil)
+	} else {
+		s.complete(err)
+	}
+
+	return err
+}
+
+func (s *clientStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.interceptor.logPayload(s.logger, "sent payload", m)
+	}
+
+	return err
+}
+
+// Logs completion of stream abandoned by cancelling its context
+func (s *clientStream) watch(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		s.complete(status.FromContextError(ctx.Err()).Err())
+	case <-s.done:
+	}
+}
+
+func (s *clientStream) complete(err error) {
+	s.once.Do(func() {
+		close(s.done)
+		s.interceptor.logCompletion(s.logger, err, s.start)
+	})
+}
@@ -0,0 +1,325 @@
+package logwgrpc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/logwgrpc"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestInterceptors(t *testing.T) {
+	suite.Run(t, new(interceptorsSuite))
+}
+
+type interceptorsSuite struct {
+	suite.Suite
+
+	serverLog *safeBuffer
+	clientLog *safeBuffer
+	server    *grpc.Server
+	conn      *grpc.ClientConn
+	client    healthpb.HealthClient
+}
+
+type safeBuffer struct {
+	mu sync.Mutex
+	b  bytes.Buffer
+}
+
+func (b *safeBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.b.Write(p)
+}
+
+func (b *safeBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.b.String()
+}
+
+func (s *interceptorsSuite) SetupTest() {
+	s.serverLog, s.clientLog = new(safeBuffer), new(safeBuffer)
+	option := logw.Option(logw.LevelDebug, logw.JSONFormatter, logw.NoDate)
+	listener := bufconn.Listen(1024 * 1024)
+
+	s.server = grpc.NewServer(
+		grpc.UnaryInterceptor(logwgrpc.UnaryServerInterceptor(s.serverLog, option, logwgrpc.LogPayloads(10))),
+		grpc.StreamInterceptor(logwgrpc.StreamServerInterceptor(s.serverLog, option)),
+	)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("test", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s.server, healthServer)
+	s.server.RegisterService(&collectorDesc, struct{}{})
+
+	server := s.server
+	go func() { _ = server.Serve(listener) }()
+
+	conn, err := grpc.DialContext(
+		context.TODO(),
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(logwgrpc.UnaryClientInterceptor(s.clientLog, option)),
+		grpc.WithStreamInterceptor(logwgrpc.StreamClientInterceptor(s.clientLog, option)),
+	)
+	s.Require().NoError(err)
+
+	s.conn = conn
+	s.client = healthpb.NewHealthClient(conn)
+}
+
+func (s *interceptorsSuite) TearDownTest() {
+	_ = s.conn.Close()
+	s.server.Stop()
+}
+
+func (s *interceptorsSuite) TestUnaryCall() {
+	ctx := metadata.AppendToOutgoingContext(context.TODO(), logwgrpc.RequestIDMetadataKey, "test-id")
+
+	_, err := s.client.Check(ctx, &healthpb.HealthCheckRequest{Service: "test"})
+	s.Require().NoError(err)
+
+	serverRecords := s.records(s.serverLog.String())
+	s.Require().Len(serverRecords, 3)
+
+	request, response, completion := serverRecords[0], serverRecords[1], serverRecords[2]
+
+	s.Equal("debug", request["level"])
+	s.Equal("request payload", request["message"])
+	s.Len(request["payload"].([]any)[0], 10)
+	s.Greater(request["payload_size"].([]any)[0], float64(10))
+	s.Equal([]any{true}, request["payload_truncated"])
+	s.Equal("debug", response["level"])
+	s.Equal("response payload", response["message"])
+
+	s.Equal("info", completion["level"])
+	s.Equal("call completed", completion["message"])
+	s.Equal([]any{"/grpc.health.v1.Health/Check"}, completion["method"])
+	s.Equal([]any{"test-id"}, completion["request_id"])
+	s.Equal([]any{"OK"}, completion["code"])
+	s.Contains(completion, "peer")
+	s.Contains(completion, "duration_ms")
+
+	clientRecords := s.records(s.clientLog.String())
+	s.Require().Len(clientRecords, 1)
+
+	s.Equal("info", clientRecords[0]["level"])
+	s.Equal([]any{"test-id"}, clientRecords[0]["request_id"])
+	s.Equal([]any{"bufnet"}, clientRecords[0]["peer"])
+}
+
+func (s *interceptorsSuite) TestPropagatesGeneratedRequestID() {
+	_, err := s.client.Check(context.TODO(), &healthpb.HealthCheckRequest{Service: "test"})
+	s.Require().NoError(err)
+
+	serverRecords := s.records(s.serverLog.String())
+	clientRecords := s.records(s.clientLog.String())
+	s.Require().NotEmpty(serverRecords)
+	s.Require().Len(clientRecords, 1)
+
+	s.NotEmpty(clientRecords[0]["request_id"])
+	s.Equal(clientRecords[0]["request_id"], serverRecords[len(serverRecords)-1]["request_id"])
+}
+
+func (s *interceptorsSuite) TestUnaryCallError() {
+	_, err := s.client.Check(context.TODO(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	s.Require().Error(err)
+
+	serverRecords := s.records(s.serverLog.String())
+	s.Require().Len(serverRecords, 2)
+
+	s.Equal("warn", serverRecords[1]["level"])
+	s.Equal([]any{"NotFound"}, serverRecords[1]["code"])
+	s.Contains(serverRecords[1], "error")
+
+	clientRecords := s.records(s.clientLog.String())
+	s.Require().Len(clientRecords, 1)
+
+	s.Equal("warn", clientRecords[0]["level"])
+}
+
+func (s *interceptorsSuite) TestStream() {
+	ctx, cancel := context.WithCancel(context.TODO())
+	ctx = metadata.AppendToOutgoingContext(ctx, logwgrpc.RequestIDMetadataKey, "test-id")
+
+	stream, err := s.client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "test"})
+	s.Require().NoError(err)
+
+	_, err = stream.Recv()
+	s.Require().NoError(err)
+
+	cancel()
+
+	_, err = stream.Recv()
+	s.Require().Error(err)
+
+	clientRecords := s.records(s.clientLog.String())
+	s.Require().Len(clientRecords, 1)
+
+	s.Equal("warn", clientRecords[0]["level"])
+	s.Equal([]any{"Canceled"}, clientRecords[0]["code"])
+	s.Equal([]any{"/grpc.health.v1.Health/Watch"}, clientRecords[0]["method"])
+	s.Equal([]any{"test-id"}, clientRecords[0]["request_id"])
+
+	s.Eventually(
+		func() bool { return len(s.records(s.serverLog.String())) == 1 },
+		time.Second,
+		time.Millisecond,
+	)
+
+	serverRecords := s.records(s.serverLog.String())
+	s.Equal([]any{"/grpc.health.v1.Health/Watch"}, serverRecords[0]["method"])
+	s.Equal([]any{"test-id"}, serverRecords[0]["request_id"])
+}
+
+func (s *interceptorsSuite) TestAbandonedStream() {
+	ctx, cancel := context.WithCancel(context.TODO())
+
+	stream, err := s.client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "test"})
+	s.Require().NoError(err)
+
+	_, err = stream.Recv()
+	s.Require().NoError(err)
+
+	cancel()
+
+	s.Eventually(
+		func() bool { return len(s.records(s.clientLog.String())) == 1 },
+		time.Second,
+		time.Millisecond,
+	)
+
+	clientRecords := s.records(s.clientLog.String())
+	s.Equal([]any{"Canceled"}, clientRecords[0]["code"])
+	s.Equal([]any{"/grpc.health.v1.Health/Watch"}, clientRecords[0]["method"])
+}
+
+func (s *interceptorsSuite) TestClientStream() {
+	stream, err := s.conn.NewStream(context.TODO(), &collectorDesc.Streams[0], "/test.Collector/Collect")
+	s.Require().NoError(err)
+
+	s.Require().NoError(stream.SendMsg(&healthpb.HealthCheckRequest{Service: "first"}))
+	s.Require().NoError(stream.SendMsg(&healthpb.HealthCheckRequest{Service: "second"}))
+	s.Require().NoError(stream.CloseSend())
+
+	response := new(healthpb.HealthCheckResponse)
+	s.Require().NoError(stream.RecvMsg(response))
+	s.Equal(healthpb.HealthCheckResponse_SERVING, response.Status)
+
+	clientRecords := s.records(s.clientLog.String())
+	s.Require().Len(clientRecords, 1)
+
+	s.Equal("info", clientRecords[0]["level"])
+	s.Equal([]any{"OK"}, clientRecords[0]["code"])
+	s.Equal([]any{"/test.Collector/Collect"}, clientRecords[0]["method"])
+}
+
+func (s *interceptorsSuite) TestPayloadTruncatedOnCharacterBoundary() {
+	handler := func(context.Context, any) (any, error) { return nil, nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Test/Call"}
+	request := &healthpb.HealthCheckRequest{Service: strings.Repeat("т", 8)}
+
+	for size := 12; size < 18; size++ {
+		b := new(bytes.Buffer)
+		interceptor := logwgrpc.UnaryServerInterceptor(
+			b,
+			logw.Option(logw.LevelDebug, logw.JSONFormatter, logw.NoDate),
+			logwgrpc.LogPayloads(size),
+		)
+
+		_, err := interceptor(context.TODO(), request, info, handler)
+		s.Require().NoError(err)
+
+		records := s.records(b.String())
+		s.Require().NotEmpty(records)
+
+		payload := records[0]["payload"].([]any)[0].(string)
+		s.True(utf8.ValidString(payload), payload)
+		s.LessOrEqual(len(payload), size)
+		s.GreaterOrEqual(len(payload), size-1)
+	}
+}
+
+func (s *interceptorsSuite) TestPayloadsSkippedAboveDebug() {
+	interceptor := logwgrpc.UnaryServerInterceptor(
+		s.serverLog,
+		logw.Option(logw.LevelInfo, logw.JSONFormatter, logw.NoDate),
+		logwgrpc.LogPayloads(0),
+	)
+	handler := func(context.Context, any) (any, error) { return nil, nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Test/Call"}
+
+	_, err := interceptor(context.TODO(), payloadFunc(func() { s.Fail("payload marshaled") }), info, handler)
+	s.Require().NoError(err)
+
+	s.Len(s.records(s.serverLog.String()), 1)
+}
+
+// Non-proto payload that reports when it is formatted
+type payloadFunc func()
+
+func (f payloadFunc) String() string {
+	f()
+	return "payload"
+}
+
+var collectorDesc = grpc.ServiceDesc{
+	ServiceName: "test.Collector",
+	HandlerType: (*any)(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Collect",
+			ClientStreams: true,
+			Handler: func(_ any, stream grpc.ServerStream) error {
+				for {
+					err := stream.RecvMsg(new(healthpb.HealthCheckRequest))
+					if err == io.EOF {
+						return stream.SendMsg(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING})
+					}
+
+					if err != nil {
+						return err
+					}
+				}
+			},
+		},
+	},
+}
+
+func (s *interceptorsSuite) records(output string) []map[string]any {
+	records := []map[string]any{}
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+
+		record := make(map[string]any)
+		s.Require().NoError(json.Unmarshal([]byte(line), &record))
+
+		records = append(records, record)
+	}
+
+	return records
+}
@@ -0,0 +1,8 @@
+// Package logwhttp provides net/http middleware that seeds request context with logw tags,
+// logs request completion and recovers panics into Error records.
+//
+// How to use:
+// 	mux.Handle("/users/", logwhttp.Route("/users/", users))
+// 	handler := logwhttp.Middleware(os.Stdout, logw.JSONOption)(mux)
+// 	http.ListenAndServe(":8080", handler)
+package logwhttp
@@ -0,0 +1,184 @@
+package logwhttp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	logw "github.com/andriiyaremenko/logwriter"
+)
+
+// Header used to read and propagate request id
+const RequestIDHeader = "X-Request-Id"
+
+type key int
+
+const (
+	requestIDKey key = iota
+	routeKey
+)
+
+// Returns middleware that seeds request context with logw tags
+// ("request_id", "method", "path", "remote_addr", "user_agent"),
+// logs completion record with "status", "bytes" and "latency_ms" tags
+// and "route" tag if request was handled by handler wrapped with Route
+// and recovers panics into Error records with "panic" and "stack" tags
+// Request id is taken from X-Request-Id header or generated
+// Completion record level depends on status class: 5xx - Error, 4xx - Warn, others - Info
+// Hijacked connections are logged with 101 status unless handler wrote status before hijacking
+// Writes to w are serialized
+func Middleware(w io.Writer, conf logw.LogWriterOption, settings ...logw.Setting) func(http.Handler) http.Handler {
+	out := logw.SyncWriter(w)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			requestID := r.Header.Get(RequestIDHeader)
+			if requestID == "" {
+				requestID = logw.NewRequestID()
+			}
+
+			route := new(string)
+
+			ctx := context.WithValue(r.Context(), requestIDKey, requestID)
+			ctx = context.WithValue(ctx, routeKey, route)
+			ctx = logw.AppendInfo(ctx, "request_id", requestID)
+			ctx = logw.AppendInfo(ctx, "method", r.Method)
+			ctx = logw.AppendInfo(ctx, "path", r.URL.Path)
+			ctx = logw.AppendInfo(ctx, "remote_addr", r.RemoteAddr)
+			ctx = logw.AppendInfo(ctx, "user_agent", r.UserAgent())
+
+			logger := log.New(logw.LogWriter(ctx, out, conf, settings...), "", 0)
+			recorder := &responseRecorder{ResponseWriter: rw, status: http.StatusOK}
+			recorder.Header().Set(RequestIDHeader, requestID)
+
+			defer func() {
+				if v := recover(); v != nil {
+					if v == http.ErrAbortHandler {
+						panic(v)
+					}
+
+					logger.Println(
+						withRoute(logw.Error, *route).
+							WithString("panic", fmt.Sprint(v)).
+							WithString("stack", string(debug.Stack())),
+						"request panicked",
+					)
+
+					if !recorder.wroteHeader {
+						recorder.WriteHeader(http.StatusInternalServerError)
+					}
+				}
+
+				logger.Println(
+					withRoute(statusLevel(recorder.status), *route).
+						WithInt("status", recorder.status).
+						WithInt("bytes", recorder.bytes).
+						WithFloat("latency_ms", float64(time.Since(start))/float64(time.Millisecond)),
+					"request completed",
+				)
+			}()
+
+			next.ServeHTTP(recorder, r.WithContext(ctx))
+		})
+	}
+}
+
+// Returns handler that adds "route" tag with route pattern to request context
+// and to Middleware completion record
+// Wrap handlers registered with router, e.g. mux.Handle("/users/", logwhttp.Route("/users/", users))
+func Route(route string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if holder, ok := r.Context().Value(routeKey).(*string); ok {
+			*holder = route
+		}
+
+		next.ServeHTTP(rw, r.WithContext(logw.AppendInfo(r.Context(), "route", route)))
+	})
+}
+
+// Returns request id seeded by Middleware
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+func withRoute(level logw.LogLevel, route string) logw.LogLevel {
+	if route == "" {
+		return level
+	}
+
+	return level.WithString("route", route)
+}
+
+func statusLevel(status int) logw.LogLevel {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return logw.Error
+	case status >= http.StatusBadRequest:
+		return logw.Warn
+	}
+
+	return logw.Info
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+
+	r.status = status
+	r.wroteHeader = true
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+
+	n, err := r.ResponseWriter.Write(p)
+	r.bytes += n
+
+	return n, err
+}
+
+func (r *responseRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (r *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("logwhttp: %T does not implement http.Hijacker", r.ResponseWriter)
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err == nil && !r.wroteHeader {
+		r.status = http.StatusSwitchingProtocols
+		r.wroteHeader = true
+	}
+
+	return conn, rw, err
+}
+
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,246 @@
+package logwhttp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/logwhttp"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestMiddleware(t *testing.T) {
+	suite.Run(t, new(middlewareSuite))
+}
+
+type middlewareSuite struct {
+	suite.Suite
+}
+
+func (s *middlewareSuite) TestCompletionRecord() {
+	b := new(bytes.Buffer)
+	handler := logwhttp.Middleware(b, logw.JSONOption)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, "hello")
+		}),
+	)
+
+	r := httptest.NewRequest(http.MethodGet, "/greeting", nil)
+	r.Header.Set(logwhttp.RequestIDHeader, "test-id")
+	r.Header.Set("User-Agent", "test-agent")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, r)
+
+	records := s.records(b)
+	s.Require().Len(records, 1)
+
+	s.Equal("test-id", w.Header().Get(logwhttp.RequestIDHeader))
+	s.Equal("info", records[0]["level"])
+	s.Equal("request completed", records[0]["message"])
+	s.Equal([]any{"test-id"}, records[0]["request_id"])
+	s.Equal([]any{"GET"}, records[0]["method"])
+	s.Equal([]any{"/greeting"}, records[0]["path"])
+	s.Equal([]any{"192.0.2.1:1234"}, records[0]["remote_addr"])
+	s.Equal([]any{"test-agent"}, records[0]["user_agent"])
+	s.Equal([]any{float64(200)}, records[0]["status"])
+	s.Equal([]any{float64(5)}, records[0]["bytes"])
+	s.Contains(records[0], "latency_ms")
+}
+
+func (s *middlewareSuite) TestGeneratesRequestID() {
+	b := new(bytes.Buffer)
+
+	var requestID string
+	handler := logwhttp.Middleware(b, logw.JSONOption)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID = logwhttp.RequestID(r.Context())
+		}),
+	)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	records := s.records(b)
+	s.Require().Len(records, 1)
+
+	s.NotEmpty(requestID)
+	s.Equal(requestID, w.Header().Get(logwhttp.RequestIDHeader))
+	s.Equal([]any{requestID}, records[0]["request_id"])
+}
+
+func (s *middlewareSuite) TestHandlerRecordsCarryRequestTags() {
+	b := new(bytes.Buffer)
+	handler := logwhttp.Middleware(b, logw.JSONOption)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			log.New(logw.JSONLogWriter(r.Context(), b), "", 0).Println("handling")
+		}),
+	)
+
+	r := httptest.NewRequest(http.MethodPost, "/items", nil)
+	r.Header.Set(logwhttp.RequestIDHeader, "test-id")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	records := s.records(b)
+	s.Require().Len(records, 2)
+
+	s.Equal("handling", records[0]["message"])
+	s.Equal([]any{"test-id"}, records[0]["request_id"])
+	s.Equal([]any{"POST"}, records[0]["method"])
+}
+
+func (s *middlewareSuite) TestRoute() {
+	b := new(bytes.Buffer)
+	mux := http.NewServeMux()
+	mux.Handle("/users/", logwhttp.Route("/users/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.New(logw.JSONLogWriter(r.Context(), b), "", 0).Println("handling")
+	})))
+
+	handler := logwhttp.Middleware(b, logw.JSONOption)(mux)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	records := s.records(b)
+	s.Require().Len(records, 3)
+
+	s.Equal("handling", records[0]["message"])
+	s.Equal([]any{"/users/"}, records[0]["route"])
+	s.Equal([]any{"/users/42"}, records[0]["path"])
+	s.Equal("request completed", records[1]["message"])
+	s.Equal([]any{"/users/"}, records[1]["route"])
+	s.Equal([]any{"/users/42"}, records[1]["path"])
+	s.NotContains(records[2], "route")
+	s.Equal([]any{"/unknown"}, records[2]["path"])
+}
+
+func (s *middlewareSuite) TestLevelByStatusClass() {
+	for status, level := range map[int]string{
+		http.StatusOK:                  "info",
+		http.StatusFound:               "info",
+		http.StatusNotFound:            "warn",
+		http.StatusServiceUnavailable:  "error",
+		http.StatusInternalServerError: "error",
+	} {
+		b := new(bytes.Buffer)
+		handler := logwhttp.Middleware(b, logw.JSONOption)(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+			}),
+		)
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		records := s.records(b)
+		s.Require().Len(records, 1)
+
+		s.Equal(level, records[0]["level"])
+		s.Equal([]any{float64(status)}, records[0]["status"])
+	}
+}
+
+func (s *middlewareSuite) TestRecoversPanic() {
+	b := new(bytes.Buffer)
+	handler := logwhttp.Middleware(b, logw.JSONOption)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("something went wrong")
+		}),
+	)
+
+	w := httptest.NewRecorder()
+	s.NotPanics(func() { handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil)) })
+
+	records := s.records(b)
+	s.Require().Len(records, 2)
+
+	s.Equal(http.StatusInternalServerError, w.Code)
+	s.Equal("error", records[0]["level"])
+	s.Equal("request panicked", records[0]["message"])
+	s.Equal([]any{"something went wrong"}, records[0]["panic"])
+	s.Contains(records[0]["stack"].([]any)[0], "runtime/debug.Stack")
+	s.Equal("error", records[1]["level"])
+	s.Equal([]any{float64(500)}, records[1]["status"])
+}
+
+func (s *middlewareSuite) TestRepanicsAbortHandler() {
+	handler := logwhttp.Middleware(io.Discard, logw.JSONOption)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		}),
+	)
+
+	s.PanicsWithValue(http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
+
+func (s *middlewareSuite) TestRequestIDWithoutMiddleware() {
+	s.Empty(logwhttp.RequestID(context.TODO()))
+}
+
+func (s *middlewareSuite) records(b *bytes.Buffer) []map[string]any {
+	records := []map[string]any{}
+	for _, line := range strings.Split(strings.TrimRight(b.String(), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+
+		record := make(map[string]any)
+		s.Require().NoError(json.Unmarshal([]byte(line), &record))
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+type notifyWriter struct {
+	b       bytes.Buffer
+	written chan struct{}
+}
+
+func (w *notifyWriter) Write(p []byte) (int, error) {
+	defer func() { w.written <- struct{}{} }()
+	return w.b.Write(p)
+}
+
+func (s *middlewareSuite) TestHijack() {
+	w := &notifyWriter{written: make(chan struct{}, 1)}
+	server := httptest.NewServer(logwhttp.Middleware(w, logw.JSONOption)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, rw, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			defer conn.Close()
+
+			_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+			_ = rw.Flush()
+		}),
+	))
+	defer server.Close()
+
+	r, err := http.NewRequest(http.MethodGet, server.URL+"/ws", nil)
+	s.Require().NoError(err)
+	r.Header.Set("Connection", "Upgrade")
+	r.Header.Set("Upgrade", "websocket")
+
+	resp, err := http.DefaultClient.Do(r)
+	s.Require().NoError(err)
+	resp.Body.Close()
+
+	s.Equal(http.StatusSwitchingProtocols, resp.StatusCode)
+
+	<-w.written
+
+	records := s.records(&w.b)
+	s.Require().Len(records, 1)
+	s.Equal([]any{float64(http.StatusSwitchingProtocols)}, records[0]["status"])
+}
@@ -0,0 +1,9 @@
+// Package logwlogrus provides logrus.Hook and logrus.Formatter that render logrus entries through logw.
+//
+// How to use:
+// 	logrus.SetFormatter(&logwlogrus.Formatter{Option: logw.JSONOption})
+//
+// or:
+// 	logrus.SetOutput(io.Discard)
+// 	logrus.AddHook(logwlogrus.NewHook(logw.JSONLogWriter(ctx, os.Stdout)))
+package logwlogrus
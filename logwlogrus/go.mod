@@ -0,0 +1,19 @@
+module github.com/andriiyaremenko/logwriter/logwlogrus
+
+go 1.18
+
+require (
+	github.com/andriiyaremenko/logwriter v0.0.0-20261019161318-49fed3452606
+	github.com/sirupsen/logrus v1.9.0
+	github.com/stretchr/testify v1.8.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// root module of the same checkout is used in development and tests
+replace github.com/andriiyaremenko/logwriter => ../
@@ -0,0 +1,106 @@
+package logwlogrus
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/sirupsen/logrus"
+)
+
+// Returns logrus.Hook that converts logrus entries and fields to logw records and writes them to w
+// w is expected to be logw.LogWriter, writes to w are serialized
+// If no levels provided hook fires for all levels
+func NewHook(w io.Writer, levels ...logrus.Level) logrus.Hook {
+	if len(levels) == 0 {
+		levels = logrus.AllLevels
+	}
+
+	return &hook{w: w, levels: levels}
+}
+
+type hook struct {
+	mu     sync.Mutex
+	w      io.Writer
+	levels []logrus.Level
+}
+
+func (h *hook) Levels() []logrus.Level {
+	return h.levels
+}
+
+func (h *hook) Fire(entry *logrus.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, err := h.w.Write([]byte(Record(entry)))
+	return err
+}
+
+// logrus.Formatter that renders entries with logw LogWriter configuration
+// logw tags of the entry context are added to the record
+type Formatter struct {
+	// LogWriter configuration
+	Option logw.LogWriterOption
+	// LogWriter additional settings
+	Settings []logw.Setting
+}
+
+func (f *Formatter) Format(entry *logrus.Entry) ([]byte, error) {
+	ctx := entry.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	b := new(bytes.Buffer)
+	if _, err := logw.LogWriter(ctx, b, f.Option, f.Settings...).Write([]byte(Record(entry))); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// Returns logw record for logrus entry
+// Entry fields are added as tags, entry caller (if reported) as "caller" tag
+func Record(entry *logrus.Entry) string {
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		keys = append(keys, key)
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+
+	level := Level(entry.Level)
+	for _, key := range keys {
+		level = level.WithAny(key, entry.Data[key])
+	}
+
+	if entry.HasCaller() {
+		level = level.WithString("caller", fmt.Sprintf("%s:%d", entry.Caller.File, entry.Caller.Line))
+	}
+
+	return level.WithMessage("%s", entry.Message)
+}
+
+// Maps logrus level to logw level
+// Panic is mapped to logw.Error, logrus itself panics on Panic level
+func Level(level logrus.Level) logw.LogLevel {
+	switch level {
+	case logrus.TraceLevel:
+		return logw.Trace
+	case logrus.DebugLevel:
+		return logw.Debug
+	case logrus.InfoLevel:
+		return logw.Info
+	case logrus.WarnLevel:
+		return logw.Warn
+	case logrus.ErrorLevel, logrus.PanicLevel:
+		return logw.Error
+	}
+
+	return logw.Fatal
+}
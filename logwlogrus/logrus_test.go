@@ -0,0 +1,105 @@
+package logwlogrus_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/logwlogrus"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestLogrus(t *testing.T) {
+	suite.Run(t, new(logrusSuite))
+}
+
+type logrusSuite struct {
+	suite.Suite
+}
+
+func (s *logrusSuite) TestHook() {
+	b := new(bytes.Buffer)
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.SetLevel(logrus.DebugLevel)
+	logger.AddHook(
+		logwlogrus.NewHook(logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelDebug, logw.JSONFormatter))),
+	)
+
+	logger.WithField("attempt", 2).Debug("debug message")
+	logger.WithFields(logrus.Fields{"retry": true, "ratio": 0.5}).Warn("warn message")
+	logger.WithError(errors.New("boom")).Error("error message")
+
+	records := s.records(b)
+	s.Require().Len(records, 3)
+
+	s.Equal("debug", records[0]["level"])
+	s.Equal("debug message", records[0]["message"])
+	s.Equal([]any{float64(2)}, records[0]["attempt"])
+
+	s.Equal("warn", records[1]["level"])
+	s.Equal([]any{true}, records[1]["retry"])
+	s.Equal([]any{0.5}, records[1]["ratio"])
+
+	s.Equal("error", records[2]["level"])
+	s.Equal([]any{"boom"}, records[2]["error"])
+}
+
+func (s *logrusSuite) TestFormatter() {
+	b := new(bytes.Buffer)
+	logger := logrus.New()
+	logger.SetOutput(b)
+	logger.SetFormatter(&logwlogrus.Formatter{Option: logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter)})
+
+	ctx := logw.AppendInfo(context.TODO(), "request_id", "test-id")
+	logger.WithContext(ctx).WithField("user", map[string]int{"id": 1}).Info("hello")
+
+	records := s.records(b)
+	s.Require().Len(records, 1)
+
+	s.Equal("info", records[0]["level"])
+	s.Equal("hello", records[0]["message"])
+	s.Equal([]any{"test-id"}, records[0]["request_id"])
+	s.Equal([]any{map[string]any{"id": float64(1)}}, records[0]["user"])
+}
+
+func (s *logrusSuite) TestFormatterWithCaller() {
+	b := new(bytes.Buffer)
+	logger := logrus.New()
+	logger.SetOutput(b)
+	logger.SetReportCaller(true)
+	logger.SetFormatter(&logwlogrus.Formatter{Option: logw.NoTimeStampOption(logw.LevelInfo, logw.TextFormatter)})
+
+	logger.Warn("careful")
+
+	s.Contains(b.String(), "logwlogrus/logrus_test.go:")
+	s.Contains(b.String(), "careful")
+}
+
+func (s *logrusSuite) TestLevel() {
+	s.Equal(logw.Trace, logwlogrus.Level(logrus.TraceLevel))
+	s.Equal(logw.Debug, logwlogrus.Level(logrus.DebugLevel))
+	s.Equal(logw.Info, logwlogrus.Level(logrus.InfoLevel))
+	s.Equal(logw.Warn, logwlogrus.Level(logrus.WarnLevel))
+	s.Equal(logw.Error, logwlogrus.Level(logrus.ErrorLevel))
+	s.Equal(logw.Fatal, logwlogrus.Level(logrus.FatalLevel))
+	s.Equal(logw.Error, logwlogrus.Level(logrus.PanicLevel))
+}
+
+func (s *logrusSuite) records(b *bytes.Buffer) []map[string]any {
+	records := []map[string]any{}
+	for _, line := range strings.Split(strings.TrimRight(b.String(), "\n"), "\n") {
+		record := make(map[string]any)
+		s.Require().NoError(json.Unmarshal([]byte(line), &record))
+
+		records = append(records, record)
+	}
+
+	return records
+}
@@ -4,12 +4,18 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"math"
 	"time"
+
+	"github.com/andriiyaremenko/logwriter/color"
 )
 
 // LogWriter configuration options
 type LogWriterOption func() (level int, f Formatter, dateFormat string)
 
+// LogWriter additional setting
+type Setting func(*logWriter)
+
 var (
 	// LogWriter configuration constructor
 	Option = func(level int, f Formatter, dateFormat string) LogWriterOption {
@@ -27,48 +33,109 @@ var (
 )
 
 // JSON LogWriter with default options
-func JSONLogWriter(ctx context.Context, w io.Writer) io.Writer {
-	return LogWriter(ctx, w, JSONOption)
+func JSONLogWriter(ctx context.Context, w io.Writer, settings ...Setting) io.Writer {
+	return LogWriter(ctx, w, JSONOption, settings...)
 }
 
 // Text LogWriter with default options
-func TextLogWriter(ctx context.Context, w io.Writer) io.Writer {
-	return LogWriter(ctx, w, TextOption)
+// Uses colors only if w is a terminal and environment allows colors
+func TextLogWriter(ctx context.Context, w io.Writer, settings ...Setting) io.Writer {
+	return LogWriter(ctx, w, Option(LevelInfo, NewTextFormatter(w, color.Auto), time.RFC3339), settings...)
 }
 
 // Generic LogWriter constructor
-func LogWriter(ctx context.Context, w io.Writer, conf LogWriterOption) io.Writer {
+func LogWriter(ctx context.Context, w io.Writer, conf LogWriterOption, settings ...Setting) io.Writer {
 	loggingLevel, formatter, dateTemplate := conf()
 
-	return &logWriter{
-		write: func(p []byte) (int, error) {
-			now := time.Now().Round(time.Millisecond)
-			level, message, tags := parseLog(p)
-
-			if level < loggingLevel {
-				return 0, nil
-			}
+	lw := &logWriter{
+		ctx:          ctx,
+		w:            w,
+		loggingLevel: loggingLevel,
+		formatter:    formatter,
+		dateTemplate: dateTemplate,
+		precision:    DefaultTimePrecision,
+		clock:        RealClock,
+		tagLevel:     math.MinInt,
+	}
 
-			tags = append(getTags(ctx, level), tags...)
-
-			return w.Write(
-				formatter(
-					FormatLogLevel(level),
-					level,
-					tags,
-					now,
-					dateTemplate,
-					bytes.TrimRight(message, "\n"),
-				),
-			)
-		},
+	for _, setting := range settings {
+		setting(lw)
 	}
+
+	return lw
 }
 
 type logWriter struct {
-	write func(p []byte) (int, error)
+	ctx          context.Context
+	w            io.Writer
+	loggingLevel int
+	formatter    Formatter
+	dateTemplate string
+	fatal        *FatalHandler
+	multiline    MultilinePolicy
+	location     *time.Location
+	precision    time.Duration
+	clock        Clock
+	timeTag      string
+	sampler      *Sampler
+	processors   []Processor
+	rules        *LevelRules
+	tagLevel     int
 }
 
 func (w *logWriter) Write(p []byte) (int, error) {
-	return w.write(p)
+	n, fatal, err := w.write(p, nil)
+	if fatal != nil {
+		w.fatal.handle(*fatal, w.w)
+	}
+
+	return n, err
+}
+
+// Writes record and returns record message if FatalHandler must run
+// Lazy tags evaluated by other LogWriters writing the same record are taken from cache
+func (w *logWriter) write(p []byte, cache lazyCache) (int, *string, error) {
+	level, message, tags := parseLog(p)
+
+	minLevel := w.loggingLevel
+	if w.rules != nil {
+		if ruleLevel, ok := w.rules.minLevel(append(getEagerTags(w.ctx), tags...)); ok {
+			minLevel = ruleLevel
+		}
+	}
+
+	if level < minLevel {
+		return 0, nil, nil
+	}
+
+	tags = append(getTags(w.ctx, w.tagLevel, level), tags...)
+	now, tags := w.recordTime(tags)
+	now = w.timeStamp(now)
+	message = bytes.TrimRight(message, "\n")
+
+	if w.sampler != nil && !w.sampler.allow(level, message, now) {
+		return 0, nil, nil
+	}
+
+	if len(w.processors) > 0 {
+		record := &Record{Level: level, Message: message, Tags: tags, Time: now}
+		for _, process := range w.processors {
+			if !process(record) {
+				return 0, nil, nil
+			}
+		}
+
+		level, message, tags, now = record.Level, record.Message, record.Tags, record.Time
+	}
+
+	resolveTags(tags, cache)
+
+	n, err := w.w.Write(w.format(level, tags, now, message))
+
+	if w.fatal != nil && isFatal(level) {
+		fatal := string(message)
+		return n, &fatal, err
+	}
+
+	return n, nil, err
 }
@@ -3,7 +3,9 @@ package logw_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"strconv"
 	"testing"
 	"time"
 
@@ -236,3 +238,86 @@ func BenchmarkLogWriterTextAllTags(b *testing.B) {
 		_, _ = writer.Write([]byte(m))
 	}
 }
+
+func BenchmarkLogWriterLevelRules(b *testing.B) {
+	rules := logw.NewLevelRules()
+	rules.SetComponent("db", logw.LevelDebug)
+	rules.SetComponent("http", logw.LevelWarn)
+	rules.SetTag("user", "test", logw.LevelTrace)
+
+	ctx := logw.AppendInfo(context.TODO(), logw.ComponentTagKey, "http")
+	writer := logw.JSONLogWriter(ctx, io.Discard, logw.Rules(rules))
+	m := []byte(logw.Debug.WithString("greeting", "Hello World").WithMessage("this going to be fun"))
+
+	for i := 0; i < b.N; i++ {
+		_, _ = writer.Write(m)
+	}
+}
+
+func BenchmarkLogWriterCallerLevelRules(b *testing.B) {
+	rules := logw.NewLevelRules()
+	rules.SetCaller("github.com/andriiyaremenko/logwriter_test", logw.LevelWarn)
+
+	writer := logw.JSONLogWriter(context.TODO(), io.Discard, logw.Rules(rules))
+	m := []byte(logw.Debug.WithMessage("this going to be fun"))
+
+	for i := 0; i < b.N; i++ {
+		_, _ = writer.Write(m)
+	}
+}
+
+func BenchmarkAppendTag(b *testing.B) {
+	ctx := context.TODO()
+	for i := 0; i < 100; i++ {
+		ctx = logw.AppendInfo(ctx, "tag", i)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = logw.AppendInfo(ctx, "tag", i)
+	}
+}
+
+func BenchmarkAppendTagFanOut(b *testing.B) {
+	ctx := logw.AppendInfo(context.TODO(), "request_id", "42")
+	ctx = logw.AppendInfo(ctx, "user", "john")
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			_ = logw.AppendInfo(ctx, "attempt", i)
+			i++
+		}
+	})
+}
+
+func BenchmarkLogWriterJSONManyContextTags(b *testing.B) {
+	ctx := context.TODO()
+	for i := 0; i < 20; i++ {
+		ctx = logw.AppendInfo(ctx, fmt.Sprintf("tag%d", i), i)
+	}
+
+	writer := logw.JSONLogWriter(ctx, io.Discard)
+
+	for i := 0; i < b.N; i++ {
+		_, _ = writer.Write([]byte("this going to be fun "))
+	}
+}
+
+func BenchmarkLogWriterJSONContextTagsDepth(b *testing.B) {
+	for _, depth := range []int{10, 100, 1000, 4000} {
+		b.Run(strconv.Itoa(depth), func(b *testing.B) {
+			ctx := context.TODO()
+			for i := 0; i < depth; i++ {
+				ctx = logw.AppendInfo(ctx, fmt.Sprintf("tag%d", i%50), i)
+			}
+
+			writer := logw.JSONLogWriter(ctx, io.Discard)
+
+			for i := 0; i < b.N; i++ {
+				_, _ = writer.Write([]byte("this going to be fun "))
+			}
+		})
+	}
+}
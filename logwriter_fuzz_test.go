@@ -5,8 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	logw "github.com/andriiyaremenko/logwriter"
 )
@@ -136,8 +138,6 @@ func FuzzLogWriterJSONInPlaceTagsWithString(f *testing.F) {
 			t.FailNow()
 		}
 
-		orig = strings.ReplaceAll(orig, "\t", " ")
-		orig = strings.ReplaceAll(orig, "\n", " ")
 		temp, _ := json.Marshal(orig)
 		orig = ""
 		_ = json.Unmarshal(temp, &orig)
@@ -187,3 +187,113 @@ func FuzzLogWriterJSONWithMessage(f *testing.F) {
 		}
 	})
 }
+
+func FuzzLogWriterInPlaceTagsRoundTrip(f *testing.F) {
+	seeds := []struct{ key, value, message string }{
+		{"tag", "Hello, world", "some message"},
+		{"tab\tkey", "new\nline", "multi\nline"},
+		{"header", "-logw-\n_level\t5\t_\n-logw-\n", "-logw-v2-\n"},
+		{"percent%0A", "%25%09%", "%0A"},
+	}
+	for _, tc := range seeds {
+		f.Add(tc.key, tc.value, tc.message) // Use f.Add to provide a seed corpus
+	}
+
+	f.Fuzz(func(t *testing.T, key, value, message string) {
+		var (
+			gotTags    []logw.Tag
+			gotMessage []byte
+		)
+
+		formatter := func(
+			level string,
+			levelCode int,
+			tags []logw.Tag,
+			timeStamp time.Time,
+			dateLayout string,
+			message []byte,
+		) []byte {
+			gotTags, gotMessage = tags, message
+			return []byte{}
+		}
+
+		w := logw.LogWriter(context.TODO(), new(bytes.Buffer), logw.NoTimeStampOption(logw.LevelInfo, formatter))
+		log := log.New(w, "", log.Lmsgprefix)
+
+		log.Print(logw.Warn.WithString(key, value).WithMessage("%s", message))
+
+		if len(gotTags) != 1 {
+			t.Errorf("LogWriter failed for %q=%q: got %d tags", key, value, len(gotTags))
+			t.FailNow()
+		}
+
+		if gotTags[0].Key != key {
+			t.Errorf("LogWriter failed for key %q, got %q", key, gotTags[0].Key)
+		}
+
+		if string(gotTags[0].Value) != value {
+			t.Errorf("LogWriter failed for value %q, got %q", value, gotTags[0].Value)
+		}
+
+		if gotTags[0].Level != logw.LevelWarn {
+			t.Errorf("LogWriter failed for %q=%q: got level %d", key, value, gotTags[0].Level)
+		}
+
+		message = strings.TrimLeft(message, " ")
+		message = strings.TrimRight(message, "\n")
+
+		if string(gotMessage) != message {
+			t.Errorf("LogWriter failed for message %q, got %q", message, gotMessage)
+		}
+	})
+}
+
+func FuzzFormattersInPlaceTagsRoundTrip(f *testing.F) {
+	seeds := []struct{ key, value string }{
+		{"tag", "Hello, world"},
+		{"tab\tkey", "new\nline"},
+		{"quote\"key", "select 1\nfrom t"},
+		{"back\\slash", "\r\n\t\"\\"},
+	}
+	for _, tc := range seeds {
+		f.Add(tc.key, tc.value) // Use f.Add to provide a seed corpus
+	}
+
+	f.Fuzz(func(t *testing.T, key, value string) {
+		switch key {
+		case "level", "levelCode", "date", "message":
+			return
+		}
+
+		b := new(bytes.Buffer)
+		log.New(logw.JSONLogWriter(context.TODO(), b), "", 0).Print(logw.Info.WithString(key, value).WithMessage("test"))
+
+		result := make(map[string]any)
+		if err := json.Unmarshal(b.Bytes(), &result); err != nil {
+			t.Errorf("JSONFormatter failed for %q=%q: %s", key, value, err)
+			t.FailNow()
+		}
+
+		jsonKey, jsonValue := key, value
+		temp, _ := json.Marshal(key)
+		_ = json.Unmarshal(temp, &jsonKey)
+		temp, _ = json.Marshal(value)
+		_ = json.Unmarshal(temp, &jsonValue)
+
+		if v, ok := result[jsonKey].([]any); !ok || len(v) != 1 || v[0] != jsonValue {
+			t.Errorf("JSONFormatter failed for %q=%q, got %v", key, value, result[jsonKey])
+		}
+
+		b.Reset()
+		w := logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelInfo, logw.TextFormatter))
+		log.New(w, "", 0).Print(logw.Info.WithString(key, value).WithMessage("test"))
+
+		if strings.Count(b.String(), "\n") != 1 {
+			t.Errorf("TextFormatter failed for %q=%q: got multiple lines %q", key, value, b.String())
+		}
+
+		if !strings.Contains(b.String(), strconv.Quote(value)) {
+			t.Errorf("TextFormatter failed for %q=%q: got %q", key, value, b.String())
+		}
+	})
+}
@@ -178,6 +178,43 @@ func (s *logWriterSuite) TestMessageWithInPlaceTags() {
 	)
 }
 
+func (s *logWriterSuite) TestMessageWithAnyInPlaceTags() {
+	b := new(bytes.Buffer)
+	test := func(
+		level string,
+		levelCode int,
+		tags []logw.Tag,
+		timeStamp time.Time,
+		message []byte,
+	) {
+		s.ElementsMatch(
+			[]logw.Tag{
+				{Key: "string", Value: []byte("test"), Type: "string", Level: 2},
+				{Key: "error", Value: []byte("some error"), Type: "string", Level: 2},
+				{Key: "bool", Value: s.marshal(true), Type: "bool", Level: 2},
+				{Key: "int64", Value: s.marshal(-42), Type: "int", Level: 2},
+				{Key: "uint64", Value: s.marshal(uint64(42)), Type: "int", Level: 2},
+				{Key: "float", Value: s.marshal(1.5), Type: "float64", Level: 2},
+				{Key: "map", Value: s.marshal(map[string]int{"a": 1}), Type: "json", Level: 2},
+			},
+			tags,
+		)
+	}
+
+	s.log.SetOutput(logw.LogWriter(context.TODO(), b, s.getTestFormatter(test)))
+	s.log.Println(
+		logw.Info.
+			WithAny("string", "test").
+			WithAny("error", errors.New("some error")).
+			WithAny("bool", true).
+			WithAny("int64", int64(-42)).
+			WithAny("uint64", uint64(42)).
+			WithAny("float", float32(1.5)).
+			WithAny("map", map[string]int{"a": 1}),
+		"test",
+	)
+}
+
 func (s *logWriterSuite) TestFormattedMessage() {
 	b := new(bytes.Buffer)
 	ctx := context.TODO()
@@ -248,6 +285,60 @@ func (s *logWriterSuite) TestWithError() {
 	s.log.Println(logw.Info.Error(errors.New("some error")))
 }
 
+func (s *logWriterSuite) TestInPlaceTagsWithSeparators() {
+	b := new(bytes.Buffer)
+	test := func(
+		level string,
+		levelCode int,
+		tags []logw.Tag,
+		timeStamp time.Time,
+		message []byte,
+	) {
+		s.Equal(3, levelCode)
+		s.Equal("warn", level)
+		s.Equal("test\n-logw-\nmore", string(message))
+		s.ElementsMatch(
+			[]logw.Tag{
+				{Key: "foo\tbar", Value: []byte("-logw-\n_level\t5\t_\n-logw-\n"), Type: "string", Level: 3},
+				{Key: "baz", Value: []byte("100%\tdone\n"), Type: "string", Level: 3},
+			},
+			tags,
+		)
+	}
+
+	s.log.SetOutput(logw.LogWriter(context.TODO(), b, s.getTestFormatter(test)))
+	s.log.Println(
+		logw.Warn.
+			WithString("foo\tbar", "-logw-\n_level\t5\t_\n-logw-\n").
+			WithString("baz", "100%\tdone\n"),
+		"test\n-logw-\nmore",
+	)
+}
+
+func (s *logWriterSuite) TestLegacyHeader() {
+	b := new(bytes.Buffer)
+	test := func(
+		level string,
+		levelCode int,
+		tags []logw.Tag,
+		timeStamp time.Time,
+		message []byte,
+	) {
+		s.Equal(4, levelCode)
+		s.Equal("error", level)
+		s.Equal("test", string(message))
+		s.ElementsMatch(
+			[]logw.Tag{
+				{Key: "foo", Value: []byte("100%25"), Type: "string", Level: 4},
+			},
+			tags,
+		)
+	}
+
+	s.log.SetOutput(logw.LogWriter(context.TODO(), b, s.getTestFormatter(test)))
+	s.log.Println("-logw-\nfoo\t100%25\tstring\n_level\t4\t_\n-logw-\n", "test")
+}
+
 func (s *logWriterSuite) getTestFormatter(
 	test func(string, int, []logw.Tag, time.Time, []byte),
 ) logw.LogWriterOption {
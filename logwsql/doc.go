@@ -0,0 +1,9 @@
+// Package logwsql provides database/sql driver wrapper that logs queries through logw.
+//
+// How to use:
+//
+//	sql.Register("postgres-logw", logwsql.Wrap(&pq.Driver{}, os.Stdout, logw.JSONOption, logwsql.SlowQuery(time.Second)))
+//	db, err := sql.Open("postgres-logw", dsn)
+//
+// Queries are logged with the logw tags of the context passed to QueryContext, ExecContext, etc.
+package logwsql
@@ -0,0 +1,349 @@
+package logwsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	logw "github.com/andriiyaremenko/logwriter"
+)
+
+// Driver wrapper option
+type Option func(*options)
+
+// Returns value to log instead of query argument value
+type Redactor func(name string, ordinal int, value any) any
+
+type options struct {
+	settings      []logw.Setting
+	slowThreshold time.Duration
+	logArgs       bool
+	redact        Redactor
+}
+
+// Queries that take longer than threshold are logged with Warn level
+func SlowQuery(threshold time.Duration) Option {
+	return func(o *options) {
+		o.slowThreshold = threshold
+	}
+}
+
+// Logs query argument values in "args" tag as JSON array
+// If redact is not nil it is applied to every argument value before logging
+func LogArgs(redact Redactor) Option {
+	return func(o *options) {
+		o.logArgs = true
+		o.redact = redact
+	}
+}
+
+// Applies logw settings to LogWriter used by driver wrapper
+func WithSettings(settings ...logw.Setting) Option {
+	return func(o *options) {
+		o.settings = append(o.settings, settings...)
+	}
+}
+
+// Wraps driver to log every query with "query", "fingerprint", "args_count", "duration_ms" tags
+// and "rows_affected" and "error" tags where applicable
+// Queries are logged with Debug level, slow queries with Warn level and failed queries with Error level
+// Records carry logw tags of the context passed to QueryContext, ExecContext, etc.
+// Writes to w are serialized
+func Wrap(d driver.Driver, w io.Writer, conf logw.LogWriterOption, opts ...Option) driver.Driver {
+	return &wrappedDriver{Driver: d, logger: newQueryLogger(w, conf, opts)}
+}
+
+// Wraps connector the same way Wrap wraps driver
+// Result can be used with sql.OpenDB
+func WrapConnector(c driver.Connector, w io.Writer, conf logw.LogWriterOption, opts ...Option) driver.Connector {
+	return &wrappedConnector{
+		connector: c,
+		driver:    &wrappedDriver{Driver: c.Driver(), logger: newQueryLogger(w, conf, opts)},
+	}
+}
+
+type queryLogger struct {
+	options
+
+	out  io.Writer
+	conf logw.LogWriterOption
+}
+
+func newQueryLogger(w io.Writer, conf logw.LogWriterOption, opts []Option) *queryLogger {
+	l := &queryLogger{out: logw.SyncWriter(w), conf: conf}
+	for _, opt := range opts {
+		opt(&l.options)
+	}
+
+	return l
+}
+
+func (l *queryLogger) log(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue,
+	start time.Time,
+	result driver.Result,
+	err error,
+) {
+	if err == driver.ErrSkip {
+		return
+	}
+
+	duration := time.Since(start)
+	level := logw.Debug
+	switch {
+	case err != nil:
+		level = logw.Error
+	case l.slowThreshold > 0 && duration > l.slowThreshold:
+		level = logw.Warn
+	}
+
+	level = level.
+		WithString("query", query).
+		WithString("fingerprint", Fingerprint(query)).
+		WithInt("args_count", len(args)).
+		WithFloat("duration_ms", float64(duration)/float64(time.Millisecond))
+
+	if l.logArgs {
+		values := make([]any, len(args))
+		for i, arg := range args {
+			values[i] = arg.Value
+			if l.redact != nil {
+				values[i] = l.redact(arg.Name, arg.Ordinal, arg.Value)
+			}
+		}
+
+		level = level.WithAny("args", values)
+	}
+
+	if result != nil {
+		if rows, rErr := result.RowsAffected(); rErr == nil {
+			level = level.WithInt("rows_affected", int(rows))
+		}
+	}
+
+	if err != nil {
+		level = level.Error(err)
+	}
+
+	log.New(logw.LogWriter(ctx, l.out, l.conf, l.settings...), "", 0).Println(level, "query completed")
+}
+
+type wrappedDriver struct {
+	driver.Driver
+
+	logger *queryLogger
+}
+
+func (d *wrappedDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wrappedConn{Conn: conn, logger: d.logger}, nil
+}
+
+type wrappedConnector struct {
+	connector driver.Connector
+	driver    *wrappedDriver
+}
+
+func (c *wrappedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wrappedConn{Conn: conn, logger: c.driver.logger}, nil
+}
+
+func (c *wrappedConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+type wrappedConn struct {
+	driver.Conn
+
+	logger *queryLogger
+}
+
+func (c *wrappedConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *wrappedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var (
+		stmt driver.Stmt
+		err  error
+	)
+
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &wrappedStmt{Stmt: stmt, query: query, logger: c.logger}, nil
+}
+
+func (c *wrappedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+
+	// same checks as database/sql applies to drivers without driver.ConnBeginTx
+	if opts.Isolation != driver.IsolationLevel(sql.LevelDefault) {
+		return nil, errors.New("sql: driver does not support non-default isolation level")
+	}
+
+	if opts.ReadOnly {
+		return nil, errors.New("sql: driver does not support read-only transactions")
+	}
+
+	return c.Conn.Begin()
+}
+
+func (c *wrappedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	c.logger.log(ctx, query, args, start, result, err)
+
+	return result, err
+}
+
+func (c *wrappedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	c.logger.log(ctx, query, args, start, nil, err)
+
+	return rows, err
+}
+
+func (c *wrappedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+
+	return nil
+}
+
+func (c *wrappedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+
+	return nil
+}
+
+func (c *wrappedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+
+	return true
+}
+
+func (c *wrappedConn) CheckNamedValue(v *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(v)
+	}
+
+	return driver.ErrSkip
+}
+
+type wrappedStmt struct {
+	driver.Stmt
+
+	query  string
+	logger *queryLogger
+}
+
+func (s *wrappedStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *wrappedStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *wrappedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	var (
+		result driver.Result
+		err    error
+	)
+
+	start := time.Now()
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		result, err = execer.ExecContext(ctx, args)
+	} else {
+		result, err = s.Stmt.Exec(values(args))
+	}
+
+	s.logger.log(ctx, s.query, args, start, result, err)
+
+	return result, err
+}
+
+func (s *wrappedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	var (
+		rows driver.Rows
+		err  error
+	)
+
+	start := time.Now()
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = queryer.QueryContext(ctx, args)
+	} else {
+		rows, err = s.Stmt.Query(values(args))
+	}
+
+	s.logger.log(ctx, s.query, args, start, nil, err)
+
+	return rows, err
+}
+
+func (s *wrappedStmt) CheckNamedValue(v *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(v)
+	}
+
+	return driver.ErrSkip
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+
+	return named
+}
+
+func values(args []driver.NamedValue) []driver.Value {
+	result := make([]driver.Value, len(args))
+	for i, arg := range args {
+		result[i] = arg.Value
+	}
+
+	return result
+}
@@ -0,0 +1,270 @@
+package logwsql_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/logwsql"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestDriver(t *testing.T) {
+	suite.Run(t, new(driverSuite))
+}
+
+type driverSuite struct {
+	suite.Suite
+
+	b  *bytes.Buffer
+	db *sql.DB
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn{}, nil }
+func (fakeConnector) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{query: query}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{}, nil }
+
+func (fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return exec(query)
+}
+
+func (fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return queryRows(query)
+}
+
+type fakeStmt struct {
+	query string
+}
+
+func (fakeStmt) Close() error                                      { return nil }
+func (fakeStmt) NumInput() int                                     { return -1 }
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) { return exec(s.query) }
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error)  { return queryRows(s.query) }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct{}
+
+func (fakeRows) Columns() []string              { return []string{"id"} }
+func (fakeRows) Close() error                   { return nil }
+func (fakeRows) Next(dest []driver.Value) error { return io.EOF }
+
+func exec(query string) (driver.Result, error) {
+	if strings.Contains(query, "slow") {
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if strings.Contains(query, "fail") {
+		return nil, errors.New("syntax error")
+	}
+
+	return driver.RowsAffected(3), nil
+}
+
+func queryRows(query string) (driver.Rows, error) {
+	if strings.Contains(query, "fail") {
+		return nil, errors.New("syntax error")
+	}
+
+	return fakeRows{}, nil
+}
+
+func (s *driverSuite) SetupTest() {
+	s.b = new(bytes.Buffer)
+	s.db = sql.OpenDB(
+		logwsql.WrapConnector(
+			fakeConnector{},
+			s.b,
+			logw.Option(logw.LevelDebug, logw.JSONFormatter, logw.NoDate),
+			logwsql.SlowQuery(10*time.Millisecond),
+		),
+	)
+}
+
+func (s *driverSuite) TearDownTest() {
+	s.NoError(s.db.Close())
+}
+
+func (s *driverSuite) TestExec() {
+	ctx := logw.AppendDebug(context.TODO(), "request_id", "test-id")
+
+	_, err := s.db.ExecContext(ctx, "UPDATE users SET name = $1 WHERE id = $2", "test", 1)
+	s.Require().NoError(err)
+
+	records := s.records()
+	s.Require().Len(records, 1)
+
+	s.Equal("debug", records[0]["level"])
+	s.Equal("query completed", records[0]["message"])
+	s.Equal([]any{"UPDATE users SET name = $1 WHERE id = $2"}, records[0]["query"])
+	s.Equal([]any{"update users set name = ? where id = ?"}, records[0]["fingerprint"])
+	s.Equal([]any{float64(2)}, records[0]["args_count"])
+	s.Equal([]any{float64(3)}, records[0]["rows_affected"])
+	s.Equal([]any{"test-id"}, records[0]["request_id"])
+	s.Contains(records[0], "duration_ms")
+	s.NotContains(records[0], "args")
+}
+
+func (s *driverSuite) TestQuery() {
+	rows, err := s.db.QueryContext(context.TODO(), "SELECT id FROM users WHERE id IN (1, 2, 3)")
+	s.Require().NoError(err)
+	s.Require().NoError(rows.Close())
+
+	records := s.records()
+	s.Require().Len(records, 1)
+
+	s.Equal("debug", records[0]["level"])
+	s.Equal([]any{"select id from users where id in (?+)"}, records[0]["fingerprint"])
+	s.NotContains(records[0], "rows_affected")
+}
+
+func (s *driverSuite) TestPreparedStatement() {
+	ctx := logw.AppendDebug(context.TODO(), "request_id", "test-id")
+
+	stmt, err := s.db.PrepareContext(ctx, "DELETE FROM users WHERE id = ?")
+	s.Require().NoError(err)
+
+	_, err = stmt.ExecContext(ctx, 1)
+	s.Require().NoError(err)
+	s.Require().NoError(stmt.Close())
+
+	records := s.records()
+	s.Require().Len(records, 1)
+
+	s.Equal([]any{"DELETE FROM users WHERE id = ?"}, records[0]["query"])
+	s.Equal([]any{float64(1)}, records[0]["args_count"])
+	s.Equal([]any{"test-id"}, records[0]["request_id"])
+}
+
+func (s *driverSuite) TestSlowQuery() {
+	_, err := s.db.ExecContext(context.TODO(), "UPDATE slow SET x = 1")
+	s.Require().NoError(err)
+
+	records := s.records()
+	s.Require().Len(records, 1)
+
+	s.Equal("warn", records[0]["level"])
+}
+
+func (s *driverSuite) TestFailedQuery() {
+	_, err := s.db.ExecContext(context.TODO(), "fail")
+	s.Require().Error(err)
+
+	records := s.records()
+	s.Require().Len(records, 1)
+
+	s.Equal("error", records[0]["level"])
+	s.Equal([]any{"syntax error"}, records[0]["error"])
+}
+
+func (s *driverSuite) TestLogArgs() {
+	b := new(bytes.Buffer)
+	redact := func(name string, ordinal int, value any) any {
+		if ordinal == 2 {
+			return "***"
+		}
+
+		return value
+	}
+
+	sql.Register(
+		"logwsql-test",
+		logwsql.Wrap(fakeDriver{}, b, logw.NoTimeStampOption(logw.LevelDebug, logw.JSONFormatter), logwsql.LogArgs(redact)),
+	)
+
+	db, err := sql.Open("logwsql-test", "")
+	s.Require().NoError(err)
+	defer db.Close()
+
+	_, err = db.Exec("INSERT INTO users (name, password) VALUES (?, ?)", "test", "secret")
+	s.Require().NoError(err)
+
+	record := make(map[string]any)
+	s.Require().NoError(json.Unmarshal(b.Bytes(), &record))
+
+	s.Equal([]any{[]any{"test", "***"}}, record["args"])
+	s.Equal([]any{"insert into users (name, password) values (?+)"}, record["fingerprint"])
+}
+
+func (s *driverSuite) TestBeginTxOptions() {
+	tx, err := s.db.BeginTx(context.TODO(), nil)
+	s.Require().NoError(err)
+	s.NoError(tx.Rollback())
+
+	_, err = s.db.BeginTx(context.TODO(), &sql.TxOptions{Isolation: sql.LevelSerializable})
+	s.EqualError(err, "sql: driver does not support non-default isolation level")
+
+	_, err = s.db.BeginTx(context.TODO(), &sql.TxOptions{ReadOnly: true})
+	s.EqualError(err, "sql: driver does not support read-only transactions")
+}
+
+func (s *driverSuite) TestCallerLevelRules() {
+	b := new(bytes.Buffer)
+	rules := logw.NewLevelRules()
+	rules.SetCaller("github.com/andriiyaremenko/logwriter/logwsql_test", logw.LevelDebug)
+
+	db := sql.OpenDB(
+		logwsql.WrapConnector(
+			fakeConnector{},
+			b,
+			logw.Option(logw.LevelWarn, logw.JSONFormatter, logw.NoDate),
+			logwsql.WithSettings(logw.Rules(rules)),
+		),
+	)
+	defer db.Close()
+
+	_, err := db.Exec("UPDATE users SET name = 'test'")
+	s.Require().NoError(err)
+
+	s.Contains(b.String(), `"level":"debug"`)
+}
+
+func (s *driverSuite) TestFingerprint() {
+	for query, fingerprint := range map[string]string{
+		"SELECT * FROM t WHERE a = 'it''s' AND b = 42.5": "select * from t where a = ? and b = ?",
+		"select *\n  from t -- comment\n where a>=:name": "select * from t where a >= ?",
+		"SELECT /* hint */ \"Name\" FROM t WHERE id=@p1": "select \"Name\" from t where id = ?",
+		"INSERT INTO t VALUES ($1, $2), ($3, $4)":        "insert into t values (?+), (?+)",
+		"SELECT f(x, y) FROM t2":                         "select f (x, y) from t2",
+	} {
+		s.Equal(fingerprint, logwsql.Fingerprint(query), query)
+	}
+}
+
+func (s *driverSuite) records() []map[string]any {
+	records := []map[string]any{}
+	for _, line := range strings.Split(strings.TrimRight(s.b.String(), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+
+		record := make(map[string]any)
+		s.Require().NoError(json.Unmarshal([]byte(line), &record))
+
+		records = append(records, record)
+	}
+
+	return records
+}
@@ -0,0 +1,111 @@
+package logwsql
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const operatorRunes = "<>=!|&+-*/%^~"
+
+var valueListMatch = regexp.MustCompile(`\(\?(?:, \?)+\)`)
+
+// Returns normalized query that is the same for queries that differ only in literal values
+// String and number literals and placeholders are replaced with "?",
+// lists of values are collapsed to "(?+)", comments are removed,
+// whitespace is collapsed and keywords and identifiers are lower-cased
+func Fingerprint(query string) string {
+	runes := []rune(query)
+	tokens := make([]string, 0, len(runes)/4)
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		next := func() rune {
+			if i+1 < len(runes) {
+				return runes[i+1]
+			}
+
+			return 0
+		}
+
+		switch {
+		case unicode.IsSpace(c):
+		case c == '-' && next() == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case c == '/' && next() == '*':
+			for i += 2; i < len(runes) && !(runes[i] == '*' && next() == '/'); i++ {
+			}
+
+			i++
+		case c == '\'':
+			for i++; i < len(runes); i++ {
+				if runes[i] != '\'' {
+					continue
+				}
+
+				if next() != '\'' {
+					break
+				}
+
+				i++
+			}
+
+			tokens = append(tokens, "?")
+		case c == '"' || c == '`':
+			start := i
+			for i++; i < len(runes) && runes[i] != c; i++ {
+			}
+
+			if i == len(runes) {
+				i--
+			}
+
+			tokens = append(tokens, string(runes[start:i+1]))
+		case (c == '$' || c == ':' || c == '@') && isIdentRune(next()):
+			for isIdentRune(next()) {
+				i++
+			}
+
+			tokens = append(tokens, "?")
+		case unicode.IsDigit(c) || (c == '.' && unicode.IsDigit(next())):
+			for isIdentRune(next()) || next() == '.' {
+				i++
+			}
+
+			tokens = append(tokens, "?")
+		case isIdentRune(c):
+			start := i
+			for isIdentRune(next()) || next() == '.' {
+				i++
+			}
+
+			tokens = append(tokens, strings.ToLower(string(runes[start:i+1])))
+		case strings.ContainsRune(operatorRunes, c):
+			start := i
+			for next() != 0 && strings.ContainsRune(operatorRunes, next()) {
+				i++
+			}
+
+			tokens = append(tokens, string(runes[start:i+1]))
+		default:
+			tokens = append(tokens, string(c))
+		}
+	}
+
+	var sb strings.Builder
+	for i, token := range tokens {
+		if i > 0 && token != "," && token != ")" && tokens[i-1] != "(" {
+			sb.WriteByte(' ')
+		}
+
+		sb.WriteString(token)
+	}
+
+	return valueListMatch.ReplaceAllString(sb.String(), "(?+)")
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,54 @@
+package logwtest
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock that returns fixed time that changes only when set or advanced
+// Use FakeClock with logw.WithClock setting to get deterministic time-stamps
+type FakeClock struct {
+	mu   sync.Mutex
+	now  time.Time
+	step time.Duration
+}
+
+// Returns FakeClock set to now
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Returns current fake time and advances it by step set with AutoAdvance
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now
+	c.now = c.now.Add(c.step)
+
+	return now
+}
+
+// Sets current fake time
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+}
+
+// Advances current fake time by d
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// Makes every Now call advance current fake time by step
+func (c *FakeClock) AutoAdvance(step time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.step = step
+}
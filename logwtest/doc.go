@@ -0,0 +1,2 @@
+// Package logwtest provides helpers for testing code that uses logw.
+package logwtest
@@ -0,0 +1,34 @@
+package logwtest
+
+import "sync"
+
+// Records would-be program exits instead of terminating
+// Use ExitRecorder.Exit as logw.FatalHandler Exit function
+type ExitRecorder struct {
+	mu    sync.Mutex
+	codes []int
+}
+
+// Records exit code
+func (r *ExitRecorder) Exit(code int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.codes = append(r.codes, code)
+}
+
+// Returns true if Exit was called at least once
+func (r *ExitRecorder) Exited() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.codes) > 0
+}
+
+// Returns recorded exit codes in order of calls
+func (r *ExitRecorder) Codes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]int(nil), r.codes...)
+}
@@ -0,0 +1,109 @@
+package logwzap
+
+import (
+	"io"
+	"sort"
+	"sync"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"go.uber.org/zap/zapcore"
+)
+
+// Returns zapcore.Core that converts zap entries and fields to logw records and writes them to w
+// w is expected to be logw.LogWriter, writes to w are serialized
+// Entry caller, logger name and stack trace are added as "caller", "logger" and "stack" tags
+func NewCore(w io.Writer, enabler zapcore.LevelEnabler) zapcore.Core {
+	return &core{LevelEnabler: enabler, w: w, mu: new(sync.Mutex)}
+}
+
+type core struct {
+	zapcore.LevelEnabler
+
+	w      io.Writer
+	mu     *sync.Mutex
+	fields []zapcore.Field
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append(append([]zapcore.Field(nil), c.fields...), fields...)
+
+	return &clone
+}
+
+func (c *core) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+
+	return checked
+}
+
+func (c *core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	keys := make([]string, 0, len(enc.Fields))
+	for key := range enc.Fields {
+		keys = append(keys, key)
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+
+	level := Level(entry.Level)
+	for _, key := range keys {
+		level = level.WithAny(key, enc.Fields[key])
+	}
+
+	if entry.Stack != "" {
+		level = level.WithString("stack", entry.Stack)
+	}
+
+	if entry.LoggerName != "" {
+		level = level.WithString("logger", entry.LoggerName)
+	}
+
+	if entry.Caller.Defined {
+		level = level.WithString("caller", entry.Caller.TrimmedPath())
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, err := c.w.Write([]byte(level.WithMessage("%s", entry.Message)))
+	return err
+}
+
+func (c *core) Sync() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if s, ok := c.w.(interface{ Sync() error }); ok {
+		return s.Sync()
+	}
+
+	return nil
+}
+
+// Maps zap level to logw level
+// DPanic and Panic are mapped to logw.Error, zap itself panics on Panic level and on DPanic level in development mode
+func Level(level zapcore.Level) logw.LogLevel {
+	switch {
+	case level < zapcore.InfoLevel:
+		return logw.Debug
+	case level == zapcore.InfoLevel:
+		return logw.Info
+	case level == zapcore.WarnLevel:
+		return logw.Warn
+	case level <= zapcore.PanicLevel:
+		return logw.Error
+	}
+
+	return logw.Fatal
+}
@@ -0,0 +1,97 @@
+package logwzap_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/logwzap"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestCore(t *testing.T) {
+	suite.Run(t, new(coreSuite))
+}
+
+type coreSuite struct {
+	suite.Suite
+}
+
+func (s *coreSuite) TestFieldsAndLevels() {
+	b := new(bytes.Buffer)
+	w := logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelDebug, logw.JSONFormatter))
+	logger := zap.New(logwzap.NewCore(w, zapcore.DebugLevel)).Named("test").With(zap.String("service", "api"))
+
+	logger.Debug("debug message", zap.Int("attempt", 2))
+	logger.Warn("warn message", zap.Bool("retry", true), zap.Float64("ratio", 0.5))
+	logger.Error("error message", zap.Error(errors.New("boom")), zap.Any("user", map[string]int{"id": 1}))
+
+	records := s.records(b)
+	s.Require().Len(records, 3)
+
+	s.Equal("debug", records[0]["level"])
+	s.Equal("debug message", records[0]["message"])
+	s.Equal([]any{"api"}, records[0]["service"])
+	s.Equal([]any{"test"}, records[0]["logger"])
+	s.Equal([]any{float64(2)}, records[0]["attempt"])
+
+	s.Equal("warn", records[1]["level"])
+	s.Equal([]any{true}, records[1]["retry"])
+	s.Equal([]any{0.5}, records[1]["ratio"])
+
+	s.Equal("error", records[2]["level"])
+	s.Equal([]any{"boom"}, records[2]["error"])
+	s.Equal([]any{map[string]any{"id": float64(1)}}, records[2]["user"])
+}
+
+func (s *coreSuite) TestLevelEnabler() {
+	b := new(bytes.Buffer)
+	w := logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelDebug, logw.JSONFormatter))
+	logger := zap.New(logwzap.NewCore(w, zapcore.WarnLevel))
+
+	logger.Info("hidden")
+	logger.Warn("visible")
+
+	records := s.records(b)
+	s.Require().Len(records, 1)
+	s.Equal("visible", records[0]["message"])
+}
+
+func (s *coreSuite) TestCallerAndStack() {
+	b := new(bytes.Buffer)
+	w := logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelDebug, logw.TextFormatter))
+	logger := zap.New(logwzap.NewCore(w, zapcore.DebugLevel), zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
+
+	logger.Error("failed")
+
+	s.Contains(b.String(), "caller:[\"logwzap/core_test.go:")
+	s.Contains(b.String(), "stack:[\"")
+}
+
+func (s *coreSuite) TestLevel() {
+	s.Equal(logw.Debug, logwzap.Level(zapcore.DebugLevel))
+	s.Equal(logw.Info, logwzap.Level(zapcore.InfoLevel))
+	s.Equal(logw.Warn, logwzap.Level(zapcore.WarnLevel))
+	s.Equal(logw.Error, logwzap.Level(zapcore.ErrorLevel))
+	s.Equal(logw.Error, logwzap.Level(zapcore.DPanicLevel))
+	s.Equal(logw.Error, logwzap.Level(zapcore.PanicLevel))
+	s.Equal(logw.Fatal, logwzap.Level(zapcore.FatalLevel))
+}
+
+func (s *coreSuite) records(b *bytes.Buffer) []map[string]any {
+	records := []map[string]any{}
+	for _, line := range strings.Split(strings.TrimRight(b.String(), "\n"), "\n") {
+		record := make(map[string]any)
+		s.Require().NoError(json.Unmarshal([]byte(line), &record))
+
+		records = append(records, record)
+	}
+
+	return records
+}
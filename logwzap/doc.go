@@ -0,0 +1,6 @@
+// Package logwzap provides zapcore.Core that renders zap entries through logw.
+//
+// How to use:
+// 	core := logwzap.NewCore(logw.JSONLogWriter(ctx, os.Stdout), zapcore.DebugLevel)
+// 	logger := zap.New(core)
+package logwzap
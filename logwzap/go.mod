@@ -0,0 +1,20 @@
+module github.com/andriiyaremenko/logwriter/logwzap
+
+go 1.18
+
+require (
+	github.com/andriiyaremenko/logwriter v0.0.0-20261019161318-49fed3452606
+	github.com/stretchr/testify v1.8.0
+	go.uber.org/zap v1.23.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.uber.org/atomic v1.7.0 // indirect
+	go.uber.org/multierr v1.6.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// root module of the same checkout is used in development and tests
+replace github.com/andriiyaremenko/logwriter => ../
@@ -0,0 +1,5 @@
+// Package logwzerolog provides writer for zerolog that renders zerolog records through logw.
+//
+// How to use:
+// 	logger := zerolog.New(logwzerolog.NewWriter(logw.JSONLogWriter(ctx, os.Stdout)))
+package logwzerolog
@@ -0,0 +1,21 @@
+module github.com/andriiyaremenko/logwriter/logwzerolog
+
+go 1.18
+
+require (
+	github.com/andriiyaremenko/logwriter v0.0.0-20261019161318-49fed3452606
+	github.com/rs/zerolog v1.28.0
+	github.com/stretchr/testify v1.8.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/mattn/go-colorable v0.1.12 // indirect
+	github.com/mattn/go-isatty v0.0.14 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// root module of the same checkout is used in development and tests
+replace github.com/andriiyaremenko/logwriter => ../
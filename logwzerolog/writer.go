@@ -0,0 +1,44 @@
+package logwzerolog
+
+import (
+	"io"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/rs/zerolog"
+)
+
+// Returns writer for zerolog.New that converts zerolog records and fields to logw records and writes them to w
+// Uses zerolog field names (zerolog.LevelFieldName, zerolog.MessageFieldName, zerolog.TimestampFieldName)
+// at the moment of the call
+// Zerolog time-stamp is kept in "time" tag
+func NewWriter(w io.Writer) io.Writer {
+	return logw.JSONInput(w, Schema())
+}
+
+// Returns logw.JSONSchema matching current zerolog field names
+func Schema() logw.JSONSchema {
+	return logw.JSONSchema{
+		LevelKey:   zerolog.LevelFieldName,
+		MessageKey: zerolog.MessageFieldName,
+		TimeKey:    zerolog.TimestampFieldName,
+	}
+}
+
+// Maps zerolog level to logw level
+// Panic is mapped to logw.Error, zerolog itself panics on Panic level
+func Level(level zerolog.Level) logw.LogLevel {
+	switch level {
+	case zerolog.TraceLevel:
+		return logw.Trace
+	case zerolog.DebugLevel:
+		return logw.Debug
+	case zerolog.InfoLevel, zerolog.NoLevel:
+		return logw.Info
+	case zerolog.WarnLevel:
+		return logw.Warn
+	case zerolog.ErrorLevel, zerolog.PanicLevel:
+		return logw.Error
+	}
+
+	return logw.Fatal
+}
@@ -0,0 +1,85 @@
+package logwzerolog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/logwzerolog"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestWriter(t *testing.T) {
+	suite.Run(t, new(writerSuite))
+}
+
+type writerSuite struct {
+	suite.Suite
+}
+
+func (s *writerSuite) TestFieldsAndLevels() {
+	b := new(bytes.Buffer)
+	w := logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelDebug, logw.JSONFormatter))
+	logger := zerolog.New(logwzerolog.NewWriter(w)).With().Str("service", "api").Timestamp().Logger()
+
+	logger.Debug().Int("attempt", 2).Msg("debug message")
+	logger.Warn().Bool("retry", true).Float64("ratio", 0.5).Msg("warn message")
+	logger.Error().Err(errors.New("boom")).Interface("user", map[string]int{"id": 1}).Msg("error message")
+
+	records := s.records(b)
+	s.Require().Len(records, 3)
+
+	s.Equal("debug", records[0]["level"])
+	s.Equal("debug message", records[0]["message"])
+	s.Equal([]any{"api"}, records[0]["service"])
+	s.Equal([]any{float64(2)}, records[0]["attempt"])
+	s.Contains(records[0], "time")
+
+	s.Equal("warn", records[1]["level"])
+	s.Equal([]any{true}, records[1]["retry"])
+	s.Equal([]any{0.5}, records[1]["ratio"])
+
+	s.Equal("error", records[2]["level"])
+	s.Equal([]any{"boom"}, records[2]["error"])
+	s.Equal([]any{map[string]any{"id": float64(1)}}, records[2]["user"])
+}
+
+func (s *writerSuite) TestLevelFiltering() {
+	b := new(bytes.Buffer)
+	w := logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter))
+	logger := zerolog.New(logwzerolog.NewWriter(w))
+
+	logger.Debug().Msg("hidden")
+	logger.Info().Msg("visible")
+
+	records := s.records(b)
+	s.Require().Len(records, 1)
+	s.Equal("visible", records[0]["message"])
+}
+
+func (s *writerSuite) TestLevel() {
+	s.Equal(logw.Trace, logwzerolog.Level(zerolog.TraceLevel))
+	s.Equal(logw.Debug, logwzerolog.Level(zerolog.DebugLevel))
+	s.Equal(logw.Info, logwzerolog.Level(zerolog.InfoLevel))
+	s.Equal(logw.Warn, logwzerolog.Level(zerolog.WarnLevel))
+	s.Equal(logw.Error, logwzerolog.Level(zerolog.ErrorLevel))
+	s.Equal(logw.Fatal, logwzerolog.Level(zerolog.FatalLevel))
+	s.Equal(logw.Error, logwzerolog.Level(zerolog.PanicLevel))
+}
+
+func (s *writerSuite) records(b *bytes.Buffer) []map[string]any {
+	records := []map[string]any{}
+	for _, line := range strings.Split(strings.TrimRight(b.String(), "\n"), "\n") {
+		record := make(map[string]any)
+		s.Require().NoError(json.Unmarshal([]byte(line), &record))
+
+		records = append(records, record)
+	}
+
+	return records
+}
@@ -0,0 +1,92 @@
+package logw
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// Policy for messages that span several lines (panics, SQL queries, pretty-printed payloads)
+type MultilinePolicy int
+
+const (
+	// Keeps multi-line messages as is
+	MultilineKeep MultilinePolicy = iota
+	// Replaces line breaks with "\n" and "\r" escape sequences
+	// Backslashes of every message are escaped as "\\", so escaped messages can be unescaped unambiguously
+	MultilineEscape
+	// Splits message into continuation records, one per line
+	// Continuation records share "record_id" tag and are numbered with "record_part" tag
+	MultilineSplit
+	// Keeps message intact, but indents continuation lines
+	// Affects only formatters that output line breaks as is (e.g. TextFormatter)
+	MultilineIndent
+)
+
+const multilineIndent = "    "
+
+// Sets LogWriter multi-line messages policy
+func Multiline(policy MultilinePolicy) Setting {
+	return func(w *logWriter) {
+		w.multiline = policy
+	}
+}
+
+func (w *logWriter) format(level int, tags []Tag, timeStamp time.Time, message []byte) []byte {
+	format := func(tags []Tag, message []byte) []byte {
+		return w.formatter(FormatLogLevel(level), level, tags, timeStamp, w.dateTemplate, message)
+	}
+
+	// backslashes are escaped even in single line messages to keep escaping reversible
+	if w.multiline == MultilineEscape {
+		message = bytes.ReplaceAll(message, []byte{'\\'}, []byte(`\\`))
+		message = bytes.ReplaceAll(message, []byte{'\r'}, []byte(`\r`))
+		message = bytes.ReplaceAll(message, []byte{'\n'}, []byte(`\n`))
+
+		return format(tags, message)
+	}
+
+	if w.multiline == MultilineKeep || !bytes.ContainsAny(message, "\r\n") {
+		return format(tags, message)
+	}
+
+	switch w.multiline {
+	case MultilineSplit:
+		id := newRecordID()
+		lines := bytes.Split(message, []byte{'\n'})
+		result := make([]byte, 0, len(message)*2)
+
+		for i, line := range lines {
+			lineTags := make([]Tag, len(tags), len(tags)+2)
+			copy(lineTags, tags)
+			lineTags = append(
+				lineTags,
+				Tag{Key: "record_id", Type: "string", Value: []byte(id), Level: level},
+				Tag{Key: "record_part", Type: "int", Value: []byte(strconv.Itoa(i + 1)), Level: level},
+			)
+
+			result = append(result, format(lineTags, bytes.TrimRight(line, "\r"))...)
+		}
+
+		return result
+	case MultilineIndent:
+		result := format(tags, message)
+		body := bytes.TrimRight(result, "\n")
+		tail := result[len(body):]
+
+		return append(bytes.ReplaceAll(body, []byte{'\n'}, []byte("\n"+multilineIndent)), tail...)
+	}
+
+	return format(tags, message)
+}
+
+func newRecordID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,146 @@
+package logw_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"testing"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/color"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestMultiline(t *testing.T) {
+	suite.Run(t, new(multilineSuite))
+}
+
+type multilineSuite struct {
+	suite.Suite
+}
+
+func (s *multilineSuite) TestKeepByDefault() {
+	b := new(bytes.Buffer)
+	log := log.New(logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelInfo, logw.TextFormatter)), "", 0)
+
+	log.Println("first\nsecond")
+
+	s.Equal(" info  first\nsecond\n", color.ClearColors(b.String()))
+
+	b.Reset()
+	log.Println("first\n\tsecond\tx")
+
+	s.Equal(" info  first\n\tsecond\tx\n", color.ClearColors(b.String()))
+}
+
+func (s *multilineSuite) TestEscape() {
+	b := new(bytes.Buffer)
+	option := logw.NoTimeStampOption(logw.LevelInfo, logw.TextFormatter)
+	log := log.New(logw.LogWriter(context.TODO(), b, option, logw.Multiline(logw.MultilineEscape)), "", 0)
+
+	log.Println("first\r\nsecond")
+
+	s.Equal(" info  first\\r\\nsecond\n", color.ClearColors(b.String()))
+
+	b.Reset()
+	option = logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter)
+	log.SetOutput(logw.LogWriter(context.TODO(), b, option, logw.Multiline(logw.MultilineEscape)))
+	log.Println("first\nsecond")
+
+	s.Equal("{\"levelCode\":2,\"level\":\"info\",\"message\":\"first\\\\nsecond\"}\n", b.String())
+
+	b.Reset()
+	log.Println(`literal \n and C:\path`)
+	log.Println("line\nbreak")
+
+	lines := strings.Split(strings.TrimRight(b.String(), "\n"), "\n")
+	s.Require().Len(lines, 2)
+	s.Equal(`literal \\n and C:\\path`, s.message(lines[0]))
+	s.Equal(`line\nbreak`, s.message(lines[1]))
+}
+
+func (s *multilineSuite) TestSplit() {
+	b := new(bytes.Buffer)
+	option := logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter)
+	log := log.New(logw.LogWriter(context.TODO(), b, option, logw.Multiline(logw.MultilineSplit)), "", 0)
+
+	log.Println(logw.Warn.WithString("foo", "bar"), "panic: test\n\ngoroutine 1 [running]:\r\nmain.main()")
+
+	lines := strings.Split(strings.TrimRight(b.String(), "\n"), "\n")
+	s.Require().Len(lines, 4)
+
+	var recordID any
+	for i, line := range lines {
+		result := make(map[string]any)
+		s.Require().NoError(json.Unmarshal([]byte(line), &result))
+
+		s.Equal("warn", result["level"])
+		s.Equal([]any{"bar"}, result["foo"])
+		s.Equal([]any{float64(i + 1)}, result["record_part"])
+
+		if i == 0 {
+			recordID = result["record_id"]
+		}
+
+		s.Equal(recordID, result["record_id"])
+	}
+
+	s.Equal("panic: test", s.message(lines[0]))
+	s.Equal("", s.message(lines[1]))
+	s.Equal("goroutine 1 [running]:", s.message(lines[2]))
+	s.Equal("main.main()", s.message(lines[3]))
+}
+
+func (s *multilineSuite) TestSplitRecordsHaveDifferentIDs() {
+	b := new(bytes.Buffer)
+	option := logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter)
+	log := log.New(logw.LogWriter(context.TODO(), b, option, logw.Multiline(logw.MultilineSplit)), "", 0)
+
+	log.Println("first\nsecond")
+	log.Println("first\nsecond")
+
+	lines := strings.Split(strings.TrimRight(b.String(), "\n"), "\n")
+	s.Require().Len(lines, 4)
+
+	ids := make(map[string]struct{})
+	for _, line := range lines {
+		result := make(map[string]any)
+		s.Require().NoError(json.Unmarshal([]byte(line), &result))
+
+		ids[result["record_id"].([]any)[0].(string)] = struct{}{}
+	}
+
+	s.Len(ids, 2)
+}
+
+func (s *multilineSuite) TestIndent() {
+	b := new(bytes.Buffer)
+	option := logw.NoTimeStampOption(logw.LevelInfo, logw.TextFormatter)
+	log := log.New(logw.LogWriter(context.TODO(), b, option, logw.Multiline(logw.MultilineIndent)), "", 0)
+
+	log.Println("first\nsecond\nthird")
+
+	s.Equal(" info  first\n    second\n    third\n", color.ClearColors(b.String()))
+
+	b.Reset()
+	log.Println(logw.Warn.WithString("foo", "bar"), "line1\n\tline2\tx\nline3")
+
+	s.Equal(" warn  foo:[\"bar\"]  line1\n    \tline2\tx\n    line3\n", color.ClearColors(b.String()))
+
+	b.Reset()
+	option = logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter)
+	log.SetOutput(logw.LogWriter(context.TODO(), b, option, logw.Multiline(logw.MultilineIndent)))
+	log.Println("first\nsecond")
+
+	s.Equal("{\"levelCode\":2,\"level\":\"info\",\"message\":\"first\\nsecond\"}\n", b.String())
+}
+
+func (s *multilineSuite) message(line string) string {
+	result := make(map[string]any)
+	s.Require().NoError(json.Unmarshal([]byte(line), &result))
+
+	m, _ := result["message"].(string)
+	return m
+}
@@ -6,7 +6,13 @@ import (
 	"strings"
 )
 
+// Returns level name for level code
+// Recognizes custom levels registered with RegisterLevel
 func FormatLogLevel(level int) string {
+	if name, ok := customLevelName(level); ok {
+		return name
+	}
+
 	switch level {
 	case LevelDebug:
 		return "debug"
@@ -25,11 +31,33 @@ func FormatLogLevel(level int) string {
 	return "fatal"
 }
 
+var (
+	fieldEscaper   = strings.NewReplacer("%", "%25", "\t", "%09", "\n", "%0A")
+	fieldUnescaper = strings.NewReplacer("%25", "%", "%09", "\t", "%0A", "\n")
+)
+
+func escapeField(s string) string {
+	return fieldEscaper.Replace(s)
+}
+
+func unescapeField(s string) string {
+	return fieldUnescaper.Replace(s)
+}
+
 func parseLog(m []byte) (int, []byte, []Tag) {
 	tags := []Tag{}
 	level := LevelInfo
 
-	sections := strings.SplitN(string(m), logwHeader, 3)
+	record := string(m)
+	header, unescape := logwHeader, unescapeField
+	v2 := strings.Index(record, logwHeader)
+	v1 := strings.Index(record, logwHeaderV1)
+
+	if v1 != -1 && (v2 == -1 || v1 < v2) {
+		header, unescape = logwHeaderV1, func(s string) string { return s }
+	}
+
+	sections := strings.SplitN(record, header, 3)
 
 	if len(sections) != 3 {
 		return level, m, tags
@@ -56,9 +84,9 @@ func parseLog(m []byte) (int, []byte, []Tag) {
 		}
 
 		tags = append(tags, Tag{
-			Key:   tagSection[0],
-			Type:  tagSection[2],
-			Value: json.RawMessage(tagSection[1]),
+			Key:   unescape(tagSection[0]),
+			Type:  unescape(tagSection[2]),
+			Value: json.RawMessage(unescape(tagSection[1])),
 		})
 	}
 
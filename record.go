@@ -0,0 +1,149 @@
+package logw
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Log record passed to processors before formatting
+type Record struct {
+	Level   int
+	Message []byte
+	Tags    []Tag
+	Time    time.Time
+}
+
+// Record processor
+// Can change record level, message, time-stamp and tags
+// Tags slice belongs to the record, but tag values may be shared with context and must not be modified in place
+// Returns false to drop record
+type Processor func(r *Record) bool
+
+// Makes LogWriter run processors on every record before formatting
+// Processors run in order they were added after level filtering and sampling
+func Process(processors ...Processor) Setting {
+	return func(w *logWriter) {
+		w.processors = append(w.processors[:len(w.processors):len(w.processors)], processors...)
+	}
+}
+
+// Returns processor that runs processors in order and stops at the first one that drops record
+func Chain(processors ...Processor) Processor {
+	return func(r *Record) bool {
+		for _, process := range processors {
+			if !process(r) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Returns processor that drops records for which keep returns false
+func Filter(keep func(r Record) bool) Processor {
+	return func(r *Record) bool {
+		return keep(*r)
+	}
+}
+
+// Returns processor that replaces every tag with the result of f or removes it if f returns false
+func MapTags(f func(tag Tag) (Tag, bool)) Processor {
+	return func(r *Record) bool {
+		tags := r.Tags[:0]
+		for _, tag := range r.Tags {
+			if tag, ok := f(tag); ok {
+				tags = append(tags, tag)
+			}
+		}
+
+		r.Tags = tags
+
+		return true
+	}
+}
+
+// Returns processor that removes tags with provided keys
+func DropTags(keys ...string) Processor {
+	drop := keySet(keys)
+
+	return MapTags(func(tag Tag) (Tag, bool) {
+		_, ok := drop[tag.Key]
+		return tag, !ok
+	})
+}
+
+// Returns processor that renames tags with key from to key to
+func RenameTag(from, to string) Processor {
+	return MapTags(func(tag Tag) (Tag, bool) {
+		if tag.Key == from {
+			tag.Key = to
+		}
+
+		return tag, true
+	})
+}
+
+// Returns processor that replaces values of tags with provided keys with RedactedValue
+func RedactTags(keys ...string) Processor {
+	redact := keySet(keys)
+
+	return MapTags(func(tag Tag) (Tag, bool) {
+		if _, ok := redact[tag.Key]; ok {
+			tag = Tag{Key: tag.Key, Value: []byte(RedactedValue), Type: "string", Level: tag.Level}
+		}
+
+		return tag, true
+	})
+}
+
+// Returns processor that adds tag with value marshaled to JSON to every record
+// Tag is added with Trace level
+func EnrichTag(key string, value any) Processor {
+	b, err := json.Marshal(value)
+	if err != nil {
+		b, _ = json.Marshal(err.Error())
+	}
+
+	tag := Tag{Key: key, Type: "json", Value: json.RawMessage(b), Level: LevelTrace}
+
+	return func(r *Record) bool {
+		r.Tags = append(r.Tags, tag)
+		return true
+	}
+}
+
+// Returns processor that moves tags with provided keys to the beginning in order of keys
+// Order of other tags is kept
+func ReorderTags(keys ...string) Processor {
+	return func(r *Record) bool {
+		tags := make([]Tag, 0, len(r.Tags))
+		for _, key := range keys {
+			for _, tag := range r.Tags {
+				if tag.Key == key {
+					tags = append(tags, tag)
+				}
+			}
+		}
+
+		order := keySet(keys)
+		for _, tag := range r.Tags {
+			if _, ok := order[tag.Key]; !ok {
+				tags = append(tags, tag)
+			}
+		}
+
+		r.Tags = tags
+
+		return true
+	}
+}
+
+func keySet(keys []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		set[key] = struct{}{}
+	}
+
+	return set
+}
@@ -0,0 +1,179 @@
+package logw_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"testing"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestRecordProcessors(t *testing.T) {
+	suite.Run(t, new(recordProcessorsSuite))
+}
+
+type recordProcessorsSuite struct {
+	suite.Suite
+
+	b *bytes.Buffer
+}
+
+func (s *recordProcessorsSuite) SetupTest() {
+	s.b = new(bytes.Buffer)
+}
+
+func (s *recordProcessorsSuite) TestProcessorsRunInOrder() {
+	order := []string{}
+	log := s.logger(
+		context.TODO(),
+		logw.Process(
+			func(r *logw.Record) bool { order = append(order, "first"); return true },
+			func(r *logw.Record) bool { order = append(order, "second"); return true },
+		),
+		logw.Process(func(r *logw.Record) bool { order = append(order, "third"); return true }),
+	)
+
+	log.Println(logw.Debug, "filtered by level")
+	log.Println(logw.Info, "test")
+
+	s.Equal([]string{"first", "second", "third"}, order)
+}
+
+func (s *recordProcessorsSuite) TestMutateRecord() {
+	log := s.logger(
+		context.TODO(),
+		logw.Process(func(r *logw.Record) bool {
+			r.Level = logw.LevelWarn
+			r.Message = append([]byte("processed: "), r.Message...)
+
+			return true
+		}),
+	)
+
+	log.Println(logw.Info, "test")
+
+	record := s.records()[0]
+	s.Equal("warn", record["level"])
+	s.Equal("processed: test", record["message"])
+}
+
+func (s *recordProcessorsSuite) TestFilter() {
+	log := s.logger(
+		context.TODO(),
+		logw.Process(logw.Filter(func(r logw.Record) bool {
+			return !bytes.HasPrefix(r.Message, []byte("health"))
+		})),
+	)
+
+	log.Println(logw.Info, "health check")
+	log.Println(logw.Info, "request")
+
+	records := s.records()
+	s.Require().Len(records, 1)
+	s.Equal("request", records[0]["message"])
+}
+
+func (s *recordProcessorsSuite) TestTagProcessors() {
+	ctx := logw.AppendInfo(context.TODO(), "password", "secret")
+	ctx = logw.AppendInfo(ctx, "usr", "test")
+	log := s.logger(
+		ctx,
+		logw.Process(
+			logw.DropTags("internal"),
+			logw.RenameTag("usr", "user"),
+			logw.RedactTags("password"),
+			logw.EnrichTag("service", "api"),
+		),
+	)
+
+	log.Println(logw.Info.WithBool("internal", true).WithInt("count", 1), "test")
+
+	record := s.records()[0]
+	s.NotContains(record, "internal")
+	s.NotContains(record, "usr")
+	s.Equal([]any{"test"}, record["user"])
+	s.Equal([]any{logw.RedactedValue}, record["password"])
+	s.Equal([]any{"api"}, record["service"])
+	s.Equal([]any{float64(1)}, record["count"])
+}
+
+func (s *recordProcessorsSuite) TestReorderTags() {
+	tags := []logw.Tag{}
+	ctx := logw.AppendInfo(context.TODO(), "a", 1)
+	ctx = logw.AppendInfo(ctx, "b", 2)
+	ctx = logw.AppendInfo(ctx, "c", 3)
+	log := s.logger(
+		ctx,
+		logw.Process(
+			logw.ReorderTags("c", "missing", "b"),
+			func(r *logw.Record) bool { tags = r.Tags; return true },
+		),
+	)
+
+	log.Println(logw.Info, "test")
+
+	keys := []string{}
+	for _, tag := range tags {
+		keys = append(keys, tag.Key)
+	}
+
+	s.Equal([]string{"c", "b", "a"}, keys)
+}
+
+func (s *recordProcessorsSuite) TestChainStopsOnDrop() {
+	called := false
+	log := s.logger(
+		context.TODO(),
+		logw.Process(logw.Chain(
+			func(r *logw.Record) bool { return r.Level >= logw.LevelWarn },
+			func(r *logw.Record) bool { called = true; return true },
+		)),
+	)
+
+	log.Println(logw.Info, "test")
+
+	s.False(called)
+	s.Empty(s.b.String())
+
+	log.Println(logw.Warn, "test")
+
+	s.True(called)
+	s.Len(s.records(), 1)
+}
+
+func (s *recordProcessorsSuite) TestProcessorsDoNotAffectContext() {
+	ctx := logw.AppendInfo(context.TODO(), "user", "test")
+	processed := s.logger(ctx, logw.Process(logw.RenameTag("user", "renamed"), logw.RedactTags("renamed")))
+	plain := s.logger(ctx)
+
+	processed.Println(logw.Info, "processed")
+	plain.Println(logw.Info, "plain")
+
+	records := s.records()
+	s.Equal([]any{logw.RedactedValue}, records[0]["renamed"])
+	s.Equal([]any{"test"}, records[1]["user"])
+}
+
+func (s *recordProcessorsSuite) logger(ctx context.Context, settings ...logw.Setting) *log.Logger {
+	return log.New(
+		logw.LogWriter(ctx, s.b, logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter), settings...),
+		"",
+		0,
+	)
+}
+
+func (s *recordProcessorsSuite) records() []map[string]any {
+	records := []map[string]any{}
+	for _, line := range strings.Split(strings.TrimSpace(s.b.String()), "\n") {
+		record := make(map[string]any)
+		s.Require().NoError(json.Unmarshal([]byte(line), &record))
+
+		records = append(records, record)
+	}
+
+	return records
+}
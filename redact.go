@@ -0,0 +1,9 @@
+package logw
+
+// Value that replaces redacted tag values
+const RedactedValue = "[REDACTED]"
+
+// Makes LogWriter replace values of tags with provided keys with RedactedValue
+func Redact(keys ...string) Setting {
+	return Process(RedactTags(keys...))
+}
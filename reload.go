@@ -0,0 +1,233 @@
+package logw
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Returns configuration for Reloader, e.g. EnvConfig
+type ConfigLoader func() (Config, error)
+
+// Returns ConfigLoader that reads JSON configuration file
+func FileConfigLoader(path string) ConfigLoader {
+	return func() (Config, error) {
+		return LoadConfig(path)
+	}
+}
+
+// Pipeline that is rebuilt when configuration changes
+// LogWriters returned by Reloader switch to the new pipeline atomically
+type Reloader struct {
+	load ConfigLoader
+
+	reloadMu sync.Mutex
+	mu       sync.RWMutex
+	pipeline *Pipeline
+	gen      uint64
+}
+
+// Loads configuration and builds initial pipeline
+func NewReloader(load ConfigLoader) (*Reloader, error) {
+	c, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline, err := c.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reloader{load: load, pipeline: pipeline}, nil
+}
+
+// Returns LogWriter that writes records with the current pipeline
+// Settings are applied after configured ones
+func (r *Reloader) LogWriter(ctx context.Context, settings ...Setting) io.Writer {
+	return &reloadWriter{reloader: r, ctx: ctx, settings: settings}
+}
+
+// Loads and validates configuration and swaps pipeline if configuration has changed
+// Logs Info record "logging configuration reloaded" with "change" tag for every changed field
+// Reloader records are written regardless of configured level
+// If configuration is invalid keeps current pipeline and logs Error record "logging configuration reload failed"
+func (r *Reloader) Reload() error {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	r.mu.RLock()
+	current := r.pipeline
+	r.mu.RUnlock()
+
+	c, err := r.load()
+	if err != nil {
+		r.logger().Println(Error.Error(err), "logging configuration reload failed")
+		return err
+	}
+
+	changes := configChanges(current.Config(), c)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	pipeline, err := c.Build()
+	if err != nil {
+		r.logger().Println(Error.Error(err), "logging configuration reload failed")
+		return err
+	}
+
+	r.mu.Lock()
+	r.pipeline = pipeline
+	r.gen++
+	r.mu.Unlock()
+
+	// in-place tags are added in reverse order
+	level := Info
+	for i := len(changes) - 1; i >= 0; i-- {
+		level = level.WithString("change", changes[i])
+	}
+
+	r.logger().Println(level, "logging configuration reloaded")
+
+	return current.Close()
+}
+
+// Default Reloader.WatchFile poll interval
+const DefaultWatchInterval = time.Second
+
+// Reloads configuration every time file at path changes
+// File is polled with interval until ctx is done
+// Non-positive interval is replaced with DefaultWatchInterval
+func (r *Reloader) WatchFile(ctx context.Context, path string, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	stat := func() (time.Time, int64) {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, -1
+		}
+
+		return info.ModTime(), info.Size()
+	}
+
+	modTime, size := stat()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				newModTime, newSize := stat()
+				if newModTime.Equal(modTime) && newSize == size {
+					continue
+				}
+
+				modTime, size = newModTime, newSize
+				_ = r.Reload()
+			}
+		}
+	}()
+}
+
+// Reloads configuration on every signal until ctx is done
+// If no signals provided SIGHUP is used
+func (r *Reloader) WatchSignal(ctx context.Context, signals ...os.Signal) {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+
+	go func() {
+		defer signal.Stop(ch)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ch:
+				_ = r.Reload()
+			}
+		}
+	}()
+}
+
+// Closes current pipeline
+func (r *Reloader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.pipeline.Close()
+}
+
+// Returns logger for Reloader records that are written regardless of configured level
+func (r *Reloader) logger() *log.Logger {
+	return log.New(
+		r.LogWriter(context.Background(), func(w *logWriter) { w.loggingLevel = LevelTrace }),
+		"",
+		0,
+	)
+}
+
+type reloadWriter struct {
+	reloader *Reloader
+	ctx      context.Context
+	settings []Setting
+
+	mu  sync.Mutex
+	gen uint64
+	w   io.Writer
+}
+
+func (w *reloadWriter) Write(p []byte) (int, error) {
+	w.reloader.mu.RLock()
+	defer w.reloader.mu.RUnlock()
+
+	w.mu.Lock()
+	if w.w == nil || w.gen != w.reloader.gen {
+		w.w = w.reloader.pipeline.LogWriter(w.ctx, w.settings...)
+		w.gen = w.reloader.gen
+	}
+
+	lw := w.w
+	w.mu.Unlock()
+
+	return lw.Write(p)
+}
+
+// Returns description of every changed configuration field
+func configChanges(prev, next Config) []string {
+	changes := make([]string, 0)
+	prevValue, nextValue := reflect.ValueOf(prev), reflect.ValueOf(next)
+
+	for i := 0; i < prevValue.NumField(); i++ {
+		prevField, nextField := prevValue.Field(i).Interface(), nextValue.Field(i).Interface()
+		if reflect.DeepEqual(prevField, nextField) {
+			continue
+		}
+
+		name := strings.Split(prevValue.Type().Field(i).Tag.Get("json"), ",")[0]
+		prevJSON, _ := json.Marshal(prevField)
+		nextJSON, _ := json.Marshal(nextField)
+
+		changes = append(changes, name+": "+string(prevJSON)+" -> "+string(nextJSON))
+	}
+
+	return changes
+}
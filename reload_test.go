@@ -0,0 +1,197 @@
+package logw_test
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestReloader(t *testing.T) {
+	suite.Run(t, new(reloaderSuite))
+}
+
+type reloaderSuite struct {
+	suite.Suite
+
+	configPath string
+	logPath    string
+	reloader   *logw.Reloader
+}
+
+func (s *reloaderSuite) SetupTest() {
+	dir := s.T().TempDir()
+	s.configPath, s.logPath = filepath.Join(dir, "logw.json"), filepath.Join(dir, "app.log")
+
+	s.writeConfig(logw.Config{Level: "info", DateLayout: "none"})
+
+	reloader, err := logw.NewReloader(logw.FileConfigLoader(s.configPath))
+	s.Require().NoError(err)
+
+	s.reloader = reloader
+}
+
+func (s *reloaderSuite) TearDownTest() {
+	s.NoError(s.reloader.Close())
+}
+
+func (s *reloaderSuite) TestReload() {
+	log := log.New(s.reloader.LogWriter(context.TODO()), "", 0)
+
+	log.Println(logw.Debug, "hidden")
+
+	s.writeConfig(logw.Config{Level: "debug", DateLayout: "none", Redact: []string{"password"}})
+	s.Require().NoError(s.reloader.Reload())
+
+	log.Println(logw.Debug.WithString("password", "secret"), "visible")
+
+	records := s.records()
+	s.Require().Len(records, 2)
+
+	s.Equal("logging configuration reloaded", records[0]["message"])
+	s.Equal(
+		[]any{`level: "info" -> "debug"`, `redact: null -> ["password"]`},
+		records[0]["change"],
+	)
+	s.Equal("visible", records[1]["message"])
+	s.Equal([]any{logw.RedactedValue}, records[1]["password"])
+
+	s.Require().NoError(s.reloader.Reload())
+	s.Len(s.records(), 2)
+}
+
+func (s *reloaderSuite) TestInvalidConfigKeepsPipeline() {
+	s.writeConfig(logw.Config{Level: "verbose", DateLayout: "none"})
+
+	err := s.reloader.Reload()
+	s.Require().Error(err)
+
+	log.New(s.reloader.LogWriter(context.TODO()), "", 0).Println(logw.Debug, "hidden")
+
+	records := s.records()
+	s.Require().Len(records, 1)
+
+	s.Equal("error", records[0]["level"])
+	s.Equal("logging configuration reload failed", records[0]["message"])
+	s.Equal([]any{err.Error()}, records[0]["error"])
+}
+
+func (s *reloaderSuite) TestWatchFile() {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	s.reloader.WatchFile(ctx, s.configPath, time.Millisecond)
+	s.writeConfig(logw.Config{Level: "warn", DateLayout: "none", Format: "text"})
+
+	s.Eventually(
+		func() bool { return strings.Contains(s.read(), "logging configuration reloaded") },
+		time.Second,
+		time.Millisecond,
+	)
+
+	log := log.New(s.reloader.LogWriter(context.TODO()), "", 0)
+	log.Println(logw.Info, "hidden")
+	log.Println(logw.Warn, "visible")
+
+	s.NotContains(s.read(), "hidden")
+	s.Contains(s.read(), " warn  visible")
+}
+
+func (s *reloaderSuite) TestWatchFileDefaultInterval() {
+	ctx, cancel := context.WithCancel(context.TODO())
+
+	s.NotPanics(func() { s.reloader.WatchFile(ctx, s.configPath, 0) })
+	s.NotPanics(func() { s.reloader.WatchFile(ctx, s.configPath, -time.Second) })
+
+	// give watchers time to start their tickers before stopping them
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+}
+
+func (s *reloaderSuite) TestWatchSignal() {
+	if runtime.GOOS == "windows" {
+		s.T().Skip("SIGHUP is not supported")
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	s.reloader.WatchSignal(ctx)
+	s.writeConfig(logw.Config{Level: "debug", DateLayout: "none"})
+	s.Require().NoError(syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	s.Eventually(
+		func() bool { return strings.Contains(s.read(), "logging configuration reloaded") },
+		time.Second,
+		time.Millisecond,
+	)
+}
+
+func (s *reloaderSuite) TestConcurrentWrites() {
+	var wg sync.WaitGroup
+	ctx := context.TODO()
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			log := log.New(s.reloader.LogWriter(ctx), "", 0)
+			for j := 0; j < 50; j++ {
+				log.Println(logw.Info, "test")
+			}
+		}()
+	}
+
+	for _, level := range []string{"debug", "warn", "info"} {
+		s.writeConfig(logw.Config{Level: level, DateLayout: "none"})
+		s.NoError(s.reloader.Reload())
+	}
+
+	wg.Wait()
+
+	s.Equal(3, strings.Count(s.read(), "logging configuration reloaded"))
+}
+
+func (s *reloaderSuite) writeConfig(c logw.Config) {
+	c.Sinks = []logw.SinkConfig{{Output: s.logPath}}
+
+	b, err := json.Marshal(c)
+	s.Require().NoError(err)
+
+	s.Require().NoError(os.WriteFile(s.configPath, b, 0o644))
+}
+
+func (s *reloaderSuite) read() string {
+	b, err := os.ReadFile(s.logPath)
+	s.Require().NoError(err)
+
+	return string(b)
+}
+
+func (s *reloaderSuite) records() []map[string]any {
+	records := []map[string]any{}
+	for _, line := range strings.Split(strings.TrimSpace(s.read()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		record := make(map[string]any)
+		s.Require().NoError(json.Unmarshal([]byte(line), &record))
+
+		records = append(records, record)
+	}
+
+	return records
+}
@@ -0,0 +1,71 @@
+package logw
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limits number of records with the same level and message written per tick
+// Sampler can be shared by several LogWriters
+type Sampler struct {
+	initial    int
+	thereafter int
+	tick       time.Duration
+
+	mu        sync.Mutex
+	tickStart time.Time
+	counts    map[string]int
+}
+
+// Default Sampler tick
+const DefaultSamplingTick = time.Second
+
+// Returns Sampler that writes first initial records with the same level and message during tick
+// and every thereafter-th record after that
+// Zero thereafter drops all records after initial ones
+// Non-positive tick is replaced with DefaultSamplingTick
+func NewSampler(initial, thereafter int, tick time.Duration) *Sampler {
+	if tick <= 0 {
+		tick = DefaultSamplingTick
+	}
+
+	return &Sampler{
+		initial:    initial,
+		thereafter: thereafter,
+		tick:       tick,
+		counts:     make(map[string]int),
+	}
+}
+
+// Makes LogWriter drop records according to sampler
+// Fatal level records are never dropped
+func Sample(s *Sampler) Setting {
+	return func(w *logWriter) {
+		w.sampler = s
+	}
+}
+
+func (s *Sampler) allow(level int, message []byte, now time.Time) bool {
+	if isFatal(level) {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.tickStart) >= s.tick {
+		s.tickStart = now
+		s.counts = make(map[string]int)
+	}
+
+	key := strconv.Itoa(level) + "\t" + string(message)
+	s.counts[key]++
+	n := s.counts[key]
+
+	if n <= s.initial {
+		return true
+	}
+
+	return s.thereafter > 0 && (n-s.initial)%s.thereafter == 0
+}
@@ -0,0 +1,116 @@
+package logw_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/logwtest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestSampling(t *testing.T) {
+	suite.Run(t, new(samplingSuite))
+}
+
+type samplingSuite struct {
+	suite.Suite
+}
+
+func (s *samplingSuite) TestSample() {
+	b := new(bytes.Buffer)
+	clock := logwtest.NewFakeClock(time.Now())
+	sampler := logw.NewSampler(1, 2, time.Second)
+	log := log.New(
+		logw.LogWriter(
+			context.TODO(),
+			b,
+			logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter),
+			logw.Sample(sampler),
+			logw.WithClock(clock),
+		),
+		"",
+		0,
+	)
+
+	for i := 0; i < 5; i++ {
+		log.Println(logw.Info, "first")
+		log.Println(logw.Warn, "first")
+		log.Println(logw.Info, "second")
+	}
+
+	s.Equal(3, strings.Count(b.String(), `"level":"info","message":"first"`))
+	s.Equal(3, strings.Count(b.String(), `"level":"warn","message":"first"`))
+	s.Equal(3, strings.Count(b.String(), `"message":"second"`))
+
+	b.Reset()
+	clock.Advance(time.Second)
+
+	log.Println(logw.Info, "first")
+	log.Println(logw.Info, "first")
+
+	s.Equal(1, strings.Count(b.String(), "first"))
+}
+
+func (s *samplingSuite) TestSamplerSharedByLogWriters() {
+	b := new(bytes.Buffer)
+	sampler := logw.NewSampler(2, 0, 0)
+
+	for i := 0; i < 5; i++ {
+		log.New(
+			logw.LogWriter(context.TODO(), b, logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter), logw.Sample(sampler)),
+			"",
+			0,
+		).Println(logw.Info, "test")
+	}
+
+	s.Equal(2, strings.Count(b.String(), "test"))
+}
+
+func (s *samplingSuite) TestFatalIsNotSampled() {
+	b := new(bytes.Buffer)
+	log := log.New(
+		logw.LogWriter(
+			context.TODO(),
+			b,
+			logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter),
+			logw.Sample(logw.NewSampler(0, 0, 0)),
+		),
+		"",
+		0,
+	)
+
+	log.Println(logw.Error, "test")
+	log.Println(logw.Fatal, "test")
+
+	s.Equal(1, strings.Count(b.String(), "test"))
+	s.Contains(b.String(), "fatal")
+}
+
+func (s *samplingSuite) TestDefaultTick() {
+	b := new(bytes.Buffer)
+	clock := logwtest.NewFakeClock(time.Now())
+	log := log.New(
+		logw.LogWriter(
+			context.TODO(),
+			b,
+			logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter),
+			logw.Sample(logw.NewSampler(1, 0, 0)),
+			logw.WithClock(clock),
+		),
+		"",
+		0,
+	)
+
+	log.Println(logw.Info, "test")
+	log.Println(logw.Info, "test")
+	s.Equal(1, strings.Count(b.String(), "test"))
+
+	clock.Advance(logw.DefaultSamplingTick)
+	log.Println(logw.Info, "test")
+	s.Equal(2, strings.Count(b.String(), "test"))
+}
@@ -0,0 +1,43 @@
+package logw
+
+import (
+	"io"
+	"sync"
+)
+
+// Returns writer that serializes writes to w
+// Useful when LogWriters created per request or per query share one output
+// Flush forwards to Flush or Sync of w and Close to Close of w if w has them
+func SyncWriter(w io.Writer) io.Writer {
+	return &syncWriter{w: w}
+}
+
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.w.Write(p)
+}
+
+func (w *syncWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return flushWriter(w.w)
+}
+
+func (w *syncWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if c, ok := w.w.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
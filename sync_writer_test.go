@@ -0,0 +1,61 @@
+package logw_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestSyncWriter(t *testing.T) {
+	suite.Run(t, new(syncWriterSuite))
+}
+
+type syncWriterSuite struct {
+	suite.Suite
+}
+
+func (s *syncWriterSuite) TestConcurrentWrites() {
+	b := new(bytes.Buffer)
+	w := logw.SyncWriter(b)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, _ = w.Write([]byte("record\n"))
+		}()
+	}
+
+	wg.Wait()
+
+	s.Equal(strings.Repeat("record\n", 50), b.String())
+}
+
+func (s *syncWriterSuite) TestForwardsFlushAndClose() {
+	b := new(flushBuffer)
+	w := logw.SyncWriter(b)
+
+	s.Require().Implements((*interface{ Flush() error })(nil), w)
+	s.Require().Implements((*io.Closer)(nil), w)
+
+	s.NoError(w.(interface{ Flush() error }).Flush())
+	s.NoError(w.(io.Closer).Close())
+
+	s.True(b.flushed)
+	s.True(b.closed)
+}
+
+func (s *syncWriterSuite) TestNewRequestID() {
+	id := logw.NewRequestID()
+
+	s.Len(id, 32)
+	s.NotEqual(id, logw.NewRequestID())
+}
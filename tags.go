@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/andriiyaremenko/logwriter/color"
 )
@@ -13,12 +14,19 @@ type Tag struct {
 	Type  string
 	Value json.RawMessage
 	Level int
+
+	lazy *lazyValue
 }
 
 type key int
 
 var logwriterKey key
 
+// Addends Tag to context, that will be logged with Trace level
+func AppendTrace(ctx context.Context, tag string, value any) context.Context {
+	return AppendTag(ctx, LevelTrace, tag, value)
+}
+
 // Addends Tag to context, that will be logged with Debug level
 func AppendDebug(ctx context.Context, tag string, value any) context.Context {
 	return AppendTag(ctx, LevelDebug, tag, value)
@@ -45,7 +53,60 @@ func AppendFatal(ctx context.Context, tag string, value any) context.Context {
 }
 
 // Addends Tag to context, that will be logged with provided level
+// Values implementing Valuer (including ValuerFunc) and func() any values are evaluated lazily
+// Tag with the same key and value already added with lower or equal level is skipped,
+// tags with the same key and different values are all logged
 func AppendTag(ctx context.Context, level int, tag string, value any) context.Context {
+	newTag, ok := makeTag(level, tag, value)
+	if !ok {
+		return ctx
+	}
+
+	return appendTag(ctx, newTag)
+}
+
+// Replaces all values of tag in context with value, that will be logged with provided level
+// Values are evaluated the same way as in AppendTag
+func SetTag(ctx context.Context, level int, tag string, value any) context.Context {
+	newTag, ok := makeTag(level, tag, value)
+	if !ok {
+		return ctx
+	}
+
+	node := contextTagNode(ctx).removeTag(tag).appendTag(newTag)
+
+	return context.WithValue(ctx, logwriterKey, node)
+}
+
+// Removes all values of tag from context
+func RemoveTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, logwriterKey, contextTagNode(ctx).removeTag(tag))
+}
+
+// Returns context that hides tags of ctx except tags with keep keys
+// Tags added to returned context are not visible in ctx
+func Scope(ctx context.Context, keep ...string) context.Context {
+	keys := keySet(keep)
+
+	var node *tagNode
+	for _, tag := range contextTags(ctx) {
+		if _, ok := keys[tag.Key]; ok {
+			node = node.appendTag(tag)
+		}
+	}
+
+	return context.WithValue(ctx, logwriterKey, node)
+}
+
+func makeTag(level int, tag string, value any) (Tag, bool) {
+	if f, ok := value.(func() any); ok {
+		value = ValuerFunc(f)
+	}
+
+	if valuer, ok := value.(Valuer); ok {
+		return Tag{Key: tag, Type: "json", Level: level, lazy: &lazyValue{valuer: valuer}}, true
+	}
+
 	err, ok := value.(error)
 	if ok {
 		value = err.Error()
@@ -55,42 +116,190 @@ func AppendTag(ctx context.Context, level int, tag string, value any) context.Co
 	if err != nil {
 		fmt.Println(color.ColorizeText(color.ANSIColorRed, fmt.Sprintf("cannot append tag %q value: %s", tag, err)))
 
-		return ctx
+		return Tag{}, false
 	}
 
-	newTag := Tag{
+	return Tag{
 		Key:   tag,
 		Type:  "json",
 		Value: json.RawMessage(b),
 		Level: level,
+	}, true
+}
+
+// Node of persistent list of context tags
+// Nodes are never modified after creation, so contexts derived from the same parent share its nodes
+// and never observe each other's tags
+type tagNode struct {
+	parent *tagNode
+	tag    Tag
+	// node hides parent tags with tag.Key instead of adding tag
+	remove bool
+	// visible tags of list up to and including this node, computed on first use
+	visible atomic.Value
+}
+
+func (n *tagNode) appendTag(tag Tag) *tagNode {
+	return &tagNode{parent: n, tag: tag}
+}
+
+func (n *tagNode) removeTag(key string) *tagNode {
+	if n == nil {
+		return nil
 	}
 
-	tags, ok := ctx.Value(logwriterKey).([]Tag)
-	if !ok {
-		return context.WithValue(ctx, logwriterKey, []Tag{newTag})
+	return &tagNode{parent: n, tag: Tag{Key: key}, remove: true}
+}
+
+// Returns tags visible from node in order they were added
+// Result is shared between calls and must not be modified
+// Tags are computed once from the nearest ancestor with computed tags
+func (n *tagNode) tags() []Tag {
+	if n == nil {
+		return nil
+	}
+
+	if tags, ok := n.visible.Load().([]Tag); ok {
+		return tags
+	}
+
+	var (
+		base  []Tag
+		nodes []*tagNode
+	)
+
+	for node := n; node != nil; node = node.parent {
+		if tags, ok := node.visible.Load().([]Tag); ok {
+			base = tags
+			break
+		}
+
+		nodes = append(nodes, node)
 	}
 
-	for _, oldTag := range tags {
-		if oldTag.Key == newTag.Key &&
-			oldTag.Level <= newTag.Level &&
-			hasSameValue(oldTag.Value, newTag.Value) {
-			return context.WithValue(ctx, logwriterKey, tags)
+	set := newTagSet(base, len(nodes))
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if nodes[i].remove {
+			set.remove(nodes[i].tag.Key)
+		} else {
+			set.add(nodes[i].tag)
 		}
 	}
 
-	return context.WithValue(ctx, logwriterKey, append(tags, newTag))
+	tags := set.result()
+	n.visible.Store(tags)
+
+	return tags
 }
 
-func getTags(ctx context.Context, level int) []Tag {
-	result := make([]Tag, 0, 1)
-	tags, ok := ctx.Value(logwriterKey).([]Tag)
-	if !ok {
-		return result
+// Ordered tags with key index used to compute visible tags
+type tagSet struct {
+	tags    []Tag
+	removed []bool
+	keys    map[string][]int
+}
+
+func newTagSet(base []Tag, capacity int) *tagSet {
+	set := &tagSet{
+		tags:    make([]Tag, 0, len(base)+capacity),
+		removed: make([]bool, 0, len(base)+capacity),
+		keys:    make(map[string][]int, len(base)+capacity),
+	}
+
+	for _, tag := range base {
+		set.keys[tag.Key] = append(set.keys[tag.Key], len(set.tags))
+		set.tags = append(set.tags, tag)
+		set.removed = append(set.removed, false)
+	}
+
+	return set
+}
+
+// Adds tag unless tag with the same key and value was added with lower or equal level
+func (s *tagSet) add(tag Tag) {
+	if tag.lazy == nil {
+		for _, i := range s.keys[tag.Key] {
+			oldTag := s.tags[i]
+			if oldTag.lazy == nil &&
+				oldTag.Level <= tag.Level &&
+				hasSameValue(oldTag.Value, tag.Value) {
+				return
+			}
+		}
+	}
+
+	s.keys[tag.Key] = append(s.keys[tag.Key], len(s.tags))
+	s.tags = append(s.tags, tag)
+	s.removed = append(s.removed, false)
+}
+
+func (s *tagSet) remove(key string) {
+	for _, i := range s.keys[key] {
+		s.removed[i] = true
+	}
+
+	delete(s.keys, key)
+}
+
+func (s *tagSet) result() []Tag {
+	result := s.tags[:0]
+	for i, tag := range s.tags {
+		if !s.removed[i] {
+			result = append(result, tag)
+		}
 	}
 
-	for _, tagValue := range tags {
-		if tagValue.Level <= level {
-			result = append(result, tagValue)
+	return result[:len(result):len(result)]
+}
+
+func contextTagNode(ctx context.Context) *tagNode {
+	node, _ := ctx.Value(logwriterKey).(*tagNode)
+	return node
+}
+
+func appendTag(ctx context.Context, newTag Tag) context.Context {
+	return context.WithValue(ctx, logwriterKey, contextTagNode(ctx).appendTag(newTag))
+}
+
+// Returns context tags of any level in order they were added, lazy tags are not evaluated
+// Tag with the same key and value as earlier tag with lower or equal level is skipped
+// Result must not be modified
+func contextTags(ctx context.Context) []Tag {
+	return contextTagNode(ctx).tags()
+}
+
+// Makes LogWriter omit context tags with level lower than provided level
+// Context tags are still omitted from records with level lower than tag level
+// In-place tags are not affected
+func TagLevel(level int) Setting {
+	return func(w *logWriter) {
+		w.tagLevel = level
+	}
+}
+
+// Returns context tags with level from minLevel to level
+// Lazy tags are not evaluated, see resolveTags
+func getTags(ctx context.Context, minLevel, level int) []Tag {
+	tags := contextTags(ctx)
+	result := make([]Tag, 0, len(tags))
+
+	for _, tag := range tags {
+		if tag.Level >= minLevel && tag.Level <= level {
+			result = append(result, tag)
+		}
+	}
+
+	return result
+}
+
+// Returns context tags of any level without evaluating lazy tags
+func getEagerTags(ctx context.Context) []Tag {
+	tags := contextTags(ctx)
+	result := make([]Tag, 0, len(tags))
+
+	for _, tag := range tags {
+		if tag.lazy == nil {
+			result = append(result, tag)
 		}
 	}
 
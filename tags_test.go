@@ -0,0 +1,224 @@
+package logw_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"testing"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestTags(t *testing.T) {
+	suite.Run(t, new(tagsSuite))
+}
+
+type tagsSuite struct {
+	suite.Suite
+}
+
+func (s *tagsSuite) write(ctx context.Context, level logw.LogLevel) map[string]any {
+	b := new(bytes.Buffer)
+	log := log.New(
+		logw.LogWriter(ctx, b, logw.NoTimeStampOption(logw.LevelTrace, logw.JSONFormatter)),
+		"",
+		0,
+	)
+
+	log.Println(level, "test")
+
+	record := make(map[string]any)
+	s.Require().NoError(json.Unmarshal(b.Bytes(), &record))
+
+	return record
+}
+
+func (s *tagsSuite) TestAppendAccumulatesValues() {
+	ctx := logw.AppendInfo(context.TODO(), "key", "a")
+	ctx = logw.AppendInfo(ctx, "key", "b")
+
+	s.Equal([]any{"a", "b"}, s.write(ctx, logw.Info)["key"])
+}
+
+func (s *tagsSuite) TestAppendSkipsDuplicate() {
+	ctx := logw.AppendDebug(context.TODO(), "key", "a")
+	ctx = logw.AppendInfo(ctx, "key", "a")
+
+	s.Equal([]any{"a"}, s.write(ctx, logw.Info)["key"])
+}
+
+func (s *tagsSuite) TestSetTagReplacesAllValues() {
+	ctx := logw.AppendInfo(context.TODO(), "key", "a")
+	ctx = logw.AppendInfo(ctx, "key", "b")
+	ctx = logw.SetTag(ctx, logw.LevelInfo, "key", "c")
+
+	s.Equal([]any{"c"}, s.write(ctx, logw.Info)["key"])
+}
+
+func (s *tagsSuite) TestSetTagReplacesLevel() {
+	ctx := logw.AppendDebug(context.TODO(), "key", "a")
+	ctx = logw.SetTag(ctx, logw.LevelError, "key", "b")
+
+	s.NotContains(s.write(ctx, logw.Info), "key")
+	s.Equal([]any{"b"}, s.write(ctx, logw.Error)["key"])
+}
+
+func (s *tagsSuite) TestAppendAfterSetTag() {
+	ctx := logw.SetTag(context.TODO(), logw.LevelInfo, "key", "a")
+	ctx = logw.AppendInfo(ctx, "key", "b")
+
+	s.Equal([]any{"a", "b"}, s.write(ctx, logw.Info)["key"])
+}
+
+func (s *tagsSuite) TestSetTagDoesNotChangeParent() {
+	parent := logw.AppendInfo(context.TODO(), "key", "a")
+	child := logw.SetTag(parent, logw.LevelInfo, "key", "b")
+
+	s.Equal([]any{"a"}, s.write(parent, logw.Info)["key"])
+	s.Equal([]any{"b"}, s.write(child, logw.Info)["key"])
+}
+
+func (s *tagsSuite) TestRemoveTag() {
+	parent := logw.AppendDebug(context.TODO(), "key", "a")
+	parent = logw.AppendError(parent, "key", "b")
+	parent = logw.AppendInfo(parent, "other", "c")
+	child := logw.RemoveTag(parent, "key")
+
+	s.NotContains(s.write(child, logw.Error), "key")
+	s.Equal([]any{"c"}, s.write(child, logw.Error)["other"])
+	s.Equal([]any{"a", "b"}, s.write(parent, logw.Error)["key"])
+}
+
+func (s *tagsSuite) TestRemoveMissingTag() {
+	ctx := logw.AppendInfo(context.TODO(), "key", "a")
+
+	s.Equal([]any{"a"}, s.write(logw.RemoveTag(ctx, "other"), logw.Info)["key"])
+	s.NotContains(s.write(logw.RemoveTag(context.TODO(), "key"), logw.Info), "key")
+}
+
+func (s *tagsSuite) TestScopeHidesParentTags() {
+	parent := logw.AppendInfo(context.TODO(), "key", "a")
+	parent = logw.AppendInfo(parent, "request_id", "1")
+	scope := logw.Scope(parent)
+
+	record := s.write(scope, logw.Info)
+	s.NotContains(record, "key")
+	s.NotContains(record, "request_id")
+}
+
+func (s *tagsSuite) TestScopeKeepsTags() {
+	parent := logw.AppendInfo(context.TODO(), "key", "a")
+	parent = logw.AppendInfo(parent, "request_id", "1")
+	scope := logw.Scope(parent, "request_id")
+
+	record := s.write(scope, logw.Info)
+	s.NotContains(record, "key")
+	s.Equal([]any{"1"}, record["request_id"])
+}
+
+func (s *tagsSuite) TestScopeTagsDoNotLeakToParent() {
+	parent := logw.AppendInfo(context.TODO(), "key", "a")
+	scope := logw.AppendInfo(logw.Scope(parent), "scoped", "b")
+
+	s.Equal([]any{"b"}, s.write(scope, logw.Info)["scoped"])
+	s.NotContains(s.write(parent, logw.Info), "scoped")
+}
+
+func (s *tagsSuite) TestSiblingsAreIsolated() {
+	parent := logw.AppendInfo(context.TODO(), "a", 1)
+	parent = logw.AppendInfo(parent, "b", 2)
+	parent = logw.AppendInfo(parent, "c", 3)
+	first := logw.AppendInfo(parent, "first", true)
+	second := logw.AppendInfo(parent, "second", true)
+
+	s.NotContains(s.write(first, logw.Info), "second")
+	s.NotContains(s.write(second, logw.Info), "first")
+}
+
+func (s *tagsSuite) TestConcurrentFanOut() {
+	parent := logw.AppendInfo(context.TODO(), "request_id", "1")
+	for i := 0; i < 10; i++ {
+		parent = logw.AppendInfo(parent, "step", i)
+	}
+
+	var wg sync.WaitGroup
+	records := make([]map[string]any, 50)
+
+	for i := range records {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			ctx := logw.AppendInfo(parent, "worker", i)
+			ctx = logw.SetTag(ctx, logw.LevelInfo, "request_id", i)
+			ctx = logw.RemoveTag(ctx, "step")
+
+			b := new(bytes.Buffer)
+			log.New(
+				logw.LogWriter(ctx, b, logw.NoTimeStampOption(logw.LevelInfo, logw.JSONFormatter)),
+				"",
+				0,
+			).Println(logw.Info, "test")
+
+			record := make(map[string]any)
+			if err := json.Unmarshal(b.Bytes(), &record); err == nil {
+				records[i] = record
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, record := range records {
+		s.Equal([]any{float64(i)}, record["worker"])
+		s.Equal([]any{float64(i)}, record["request_id"])
+		s.NotContains(record, "step")
+	}
+
+	s.Equal([]any{"1"}, s.write(parent, logw.Info)["request_id"])
+	s.NotContains(s.write(parent, logw.Info), "worker")
+}
+
+func (s *tagsSuite) TestTagLevel() {
+	ctx := logw.AppendTrace(context.TODO(), "trace", 1)
+	ctx = logw.AppendDebug(ctx, "debug", 2)
+	ctx = logw.AppendInfo(ctx, "info", 3)
+	ctx = logw.AppendError(ctx, "error", 4)
+
+	b := new(bytes.Buffer)
+	log := log.New(
+		logw.LogWriter(
+			ctx,
+			b,
+			logw.NoTimeStampOption(logw.LevelDebug, logw.JSONFormatter),
+			logw.TagLevel(logw.LevelInfo),
+		),
+		"",
+		0,
+	)
+
+	log.Println(logw.Warn.WithInt("in_place", 5), "test")
+
+	record := make(map[string]any)
+	s.Require().NoError(json.Unmarshal(b.Bytes(), &record))
+
+	s.NotContains(record, "trace")
+	s.NotContains(record, "debug")
+	s.Equal([]any{float64(3)}, record["info"])
+	s.NotContains(record, "error")
+	s.Equal([]any{float64(5)}, record["in_place"])
+
+	b.Reset()
+	log.Println(logw.Debug.WithInt("in_place", 6), "test")
+
+	record = make(map[string]any)
+	s.Require().NoError(json.Unmarshal(b.Bytes(), &record))
+
+	s.NotContains(record, "debug")
+	s.NotContains(record, "info")
+	s.Equal([]any{float64(6)}, record["in_place"])
+}
@@ -0,0 +1,106 @@
+package logw
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	// Date layout to log unix time-stamp in seconds as number
+	UnixSeconds = "UNIX_SECONDS"
+	// Date layout to log unix time-stamp in milliseconds as number
+	UnixMillis = "UNIX_MILLIS"
+	// Date layout to log unix time-stamp in nanoseconds as number
+	UnixNanos = "UNIX_NANOS"
+	// Date layout to log seconds elapsed since program start as number
+	// Uses monotonic clock
+	Elapsed = "ELAPSED"
+)
+
+// Default LogWriter time-stamp precision
+const DefaultTimePrecision = time.Millisecond
+
+var programStart = time.Now()
+
+// Sets time zone of LogWriter time-stamps
+// By default JSONFormatter uses UTC and TextFormatter uses local time
+// time.Local sets local time zone for all formatters
+func TimeZone(loc *time.Location) Setting {
+	if loc == time.Local {
+		loc = localLocation()
+	}
+
+	return func(w *logWriter) {
+		w.location = loc
+	}
+}
+
+// Sets local time zone of LogWriter time-stamps for all formatters
+func LocalTimeZone() Setting {
+	return TimeZone(time.Local)
+}
+
+// Sets IANA time zone of LogWriter time-stamps, e.g. "Europe/Kyiv"
+func NamedTimeZone(name string) (Setting, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return TimeZone(loc), nil
+}
+
+// Sets LogWriter time-stamps precision, e.g. time.Second or time.Microsecond
+// Zero or negative precision keeps nanoseconds
+func TimePrecision(precision time.Duration) Setting {
+	return func(w *logWriter) {
+		w.precision = precision
+	}
+}
+
+func (w *logWriter) timeStamp(now time.Time) time.Time {
+	if w.dateTemplate == Elapsed {
+		elapsed := now.Sub(programStart)
+		if w.precision > 0 {
+			elapsed = elapsed.Round(w.precision)
+		}
+
+		return programStart.Add(elapsed)
+	}
+
+	if w.precision > 0 {
+		now = now.Round(w.precision)
+	}
+
+	if w.location != nil {
+		now = now.In(w.location)
+	}
+
+	return now
+}
+
+// Returns time-stamp formatted with date layout and true if result is a number
+func formatTimeStamp(timeStamp time.Time, dateLayout string) (string, bool) {
+	switch dateLayout {
+	case UnixSeconds:
+		return strconv.FormatInt(timeStamp.Unix(), 10), true
+	case UnixMillis:
+		return strconv.FormatInt(timeStamp.UnixMilli(), 10), true
+	case UnixNanos:
+		return strconv.FormatInt(timeStamp.UnixNano(), 10), true
+	case Elapsed:
+		return strconv.FormatFloat(timeStamp.Sub(programStart).Seconds(), 'f', -1, 64), true
+	}
+
+	return timeStamp.Format(dateLayout), false
+}
+
+// Returns copy of local time zone with the same name and transitions
+// Formatters treat time.Local as default time zone, so it can not be used to set local time zone explicitly
+func localLocation() *time.Location {
+	// time.Local is loaded on first use
+	_, _ = time.Now().In(time.Local).Zone()
+
+	loc := *time.Local
+	return &loc
+}
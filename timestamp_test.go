@@ -0,0 +1,128 @@
+package logw_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	logw "github.com/andriiyaremenko/logwriter"
+	"github.com/andriiyaremenko/logwriter/logwtest"
+	"github.com/andriiyaremenko/logwriter/color"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestTimeStamp(t *testing.T) {
+	suite.Run(t, new(timeStampSuite))
+}
+
+type timeStampSuite struct {
+	suite.Suite
+}
+
+func (s *timeStampSuite) TestDefaultTimeZones() {
+	jsonRecord := s.jsonRecord(time.RFC3339Nano)
+	s.True(strings.HasSuffix(jsonRecord["date"].(string), "Z"))
+
+	text := s.text(time.RFC3339)
+	s.Contains(text, time.Now().Format("Z07:00")+"  test")
+}
+
+func (s *timeStampSuite) TestTimeZone() {
+	record := s.jsonRecord(time.RFC3339, logw.TimeZone(time.FixedZone("test", -2*60*60)))
+	s.True(strings.HasSuffix(record["date"].(string), "-02:00"))
+
+	s.Contains(s.text(time.RFC3339, logw.TimeZone(time.UTC)), "Z  test")
+}
+
+func (s *timeStampSuite) TestNamedTimeZone() {
+	setting, err := logw.NamedTimeZone("Asia/Tokyo")
+	s.Require().NoError(err)
+
+	record := s.jsonRecord(time.RFC3339, setting)
+	s.True(strings.HasSuffix(record["date"].(string), "+09:00"))
+
+	_, err = logw.NamedTimeZone("Nowhere/Unknown")
+	s.Error(err)
+}
+
+func (s *timeStampSuite) TestLocalTimeZone() {
+	newYork, err := time.LoadLocation("America/New_York")
+	s.Require().NoError(err)
+
+	local := time.Local
+	time.Local = newYork
+	defer func() { time.Local = local }()
+
+	summer := logw.WithClock(logwtest.NewFakeClock(time.Date(2022, time.July, 1, 12, 0, 0, 0, time.UTC)))
+	winter := logw.WithClock(logwtest.NewFakeClock(time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC)))
+
+	for _, setting := range []logw.Setting{logw.LocalTimeZone(), logw.TimeZone(time.Local)} {
+		s.Equal("2022-07-01T08:00:00-04:00", s.jsonRecord(time.RFC3339, setting, summer)["date"])
+		s.Equal("2022-01-01T07:00:00-05:00", s.jsonRecord(time.RFC3339, setting, winter)["date"])
+	}
+}
+
+func (s *timeStampSuite) TestTimePrecision() {
+	record := s.jsonRecord(time.RFC3339Nano, logw.TimePrecision(time.Second))
+	s.NotContains(record["date"], ".")
+
+	date, err := time.Parse(time.RFC3339Nano, s.jsonRecord(time.RFC3339Nano, logw.TimePrecision(0))["date"].(string))
+	s.Require().NoError(err)
+	s.WithinDuration(time.Now(), date, time.Second)
+}
+
+func (s *timeStampSuite) TestEpoch() {
+	before := time.Now()
+	seconds := s.jsonRecord(logw.UnixSeconds)["date"]
+	millis := s.jsonRecord(logw.UnixMillis)["date"]
+	nanos := s.jsonRecord(logw.UnixNanos)["date"]
+	after := time.Now()
+
+	s.IsType(float64(0), seconds)
+	s.InDelta(float64(before.Unix()), seconds, 1)
+	s.InDelta(float64(before.UnixMilli()), millis, float64(after.Sub(before).Milliseconds()+1))
+	s.InDelta(float64(before.UnixNano()), nanos, float64(time.Second))
+
+	s.Regexp(`^ info\s+\d+\s+test\n$`, s.text(logw.UnixMillis))
+}
+
+func (s *timeStampSuite) TestElapsed() {
+	elapsed := s.jsonRecord(logw.Elapsed)["date"]
+
+	s.IsType(float64(0), elapsed)
+	s.Greater(elapsed, float64(0))
+	s.Less(elapsed, float64(time.Hour/time.Second))
+}
+
+func (s *timeStampSuite) jsonRecord(dateLayout string, settings ...logw.Setting) map[string]any {
+	b := new(bytes.Buffer)
+	log := log.New(
+		logw.LogWriter(context.TODO(), b, logw.Option(logw.LevelInfo, logw.JSONFormatter, dateLayout), settings...),
+		"",
+		0,
+	)
+
+	log.Println(logw.Info, "test")
+
+	record := make(map[string]any)
+	s.Require().NoError(json.Unmarshal(b.Bytes(), &record))
+
+	return record
+}
+
+func (s *timeStampSuite) text(dateLayout string, settings ...logw.Setting) string {
+	b := new(bytes.Buffer)
+	log := log.New(
+		logw.LogWriter(context.TODO(), b, logw.Option(logw.LevelInfo, logw.NewTextFormatter(b, color.Never), dateLayout), settings...),
+		"",
+		0,
+	)
+
+	log.Println(logw.Info, "test")
+
+	return b.String()
+}